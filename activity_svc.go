@@ -1,8 +1,12 @@
 package garmin
 
 import (
+	"errors"
 	"fmt"
 	"net/url"
+	"time"
+
+	"github.com/jylitalo/go-garmin/units"
 )
 
 type ActivityService service
@@ -41,6 +45,14 @@ type TimeZoneUnit struct {
 	TimeZone string  `json:"timeZone"`
 }
 
+// Location loads the IANA time zone named by TimeZone.
+func (tz *TimeZoneUnit) Location() (*time.Location, error) {
+	if len(tz.TimeZone) == 0 {
+		return nil, errors.New("time zone unit has no time zone")
+	}
+	return time.LoadLocation(tz.TimeZone)
+}
+
 type ActivityMetadataDTO struct {
 	IsOriginal                      bool `json:"isOriginal"`
 	DeviceApplicationInstallationID int  `json:"deviceApplicationInstallationId"`
@@ -51,8 +63,8 @@ type ActivityMetadataDTO struct {
 		FormatKey string `json:"formatKey"`
 	} `json:"fileFormat"`
 	AssociatedCourseID  any              `json:"associatedCourseId"`
-	LastUpdateDate      string           `json:"lastUpdateDate"`
-	UploadedDate        string           `json:"uploadedDate"`
+	LastUpdateDate      GarminGMTTime    `json:"lastUpdateDate"`
+	UploadedDate        GarminGMTTime    `json:"uploadedDate"`
 	VideoURL            any              `json:"videoUrl"`
 	HasPolyline         bool             `json:"hasPolyline"`
 	HasChartData        bool             `json:"hasChartData"`
@@ -103,55 +115,73 @@ type ActivityUserInfo struct {
 }
 
 type ActivitySummaryDTO struct {
-	StartTimeLocal                 string  `json:"startTimeLocal"`
-	StartTimeGMT                   string  `json:"startTimeGMT"`
-	StartLatitude                  float64 `json:"startLatitude"`
-	StartLongitude                 float64 `json:"startLongitude"`
-	Distance                       float64 `json:"distance"`
-	Duration                       float64 `json:"duration"`
-	MovingDuration                 float64 `json:"movingDuration"`
-	ElapsedDuration                float64 `json:"elapsedDuration"`
-	ElevationGain                  float64 `json:"elevationGain"`
-	ElevationLoss                  float64 `json:"elevationLoss"`
-	MaxElevation                   float64 `json:"maxElevation"`
-	MinElevation                   float64 `json:"minElevation"`
-	AverageSpeed                   float64 `json:"averageSpeed"`
-	AverageMovingSpeed             float64 `json:"averageMovingSpeed"`
-	MaxSpeed                       float64 `json:"maxSpeed"`
-	Calories                       float64 `json:"calories"`
-	BmrCalories                    float64 `json:"bmrCalories"`
-	AverageHR                      float64 `json:"averageHR"`
-	MaxHR                          float64 `json:"maxHR"`
-	AverageRunCadence              float64 `json:"averageRunCadence"`
-	MaxRunCadence                  float64 `json:"maxRunCadence"`
-	AveragePower                   float64 `json:"averagePower"`
-	MaxPower                       float64 `json:"maxPower"`
-	MinPower                       float64 `json:"minPower"`
-	NormalizedPower                float64 `json:"normalizedPower"`
-	TotalWork                      float64 `json:"totalWork"`
-	GroundContactTime              float64 `json:"groundContactTime"`
-	StrideLength                   float64 `json:"strideLength"`
-	VerticalOscillation            float64 `json:"verticalOscillation"`
-	TrainingEffect                 float64 `json:"trainingEffect"`
-	AnaerobicTrainingEffect        float64 `json:"anaerobicTrainingEffect"`
-	AerobicTrainingEffectMessage   string  `json:"aerobicTrainingEffectMessage"`
-	AnaerobicTrainingEffectMessage string  `json:"anaerobicTrainingEffectMessage"`
-	VerticalRatio                  float64 `json:"verticalRatio"`
-	EndLatitude                    float64 `json:"endLatitude"`
-	EndLongitude                   float64 `json:"endLongitude"`
-	MaxVerticalSpeed               float64 `json:"maxVerticalSpeed"`
-	WaterEstimated                 float64 `json:"waterEstimated"`
-	TrainingEffectLabel            string  `json:"trainingEffectLabel"`
-	ActivityTrainingLoad           float64 `json:"activityTrainingLoad"`
-	MinActivityLapDuration         float64 `json:"minActivityLapDuration"`
-	DirectWorkoutFeel              int     `json:"directWorkoutFeel"`
-	DirectWorkoutRpe               int     `json:"directWorkoutRpe"`
-	ModerateIntensityMinutes       int     `json:"moderateIntensityMinutes"`
-	VigorousIntensityMinutes       int     `json:"vigorousIntensityMinutes"`
-	Steps                          int     `json:"steps"`
-	RecoveryHeartRate              int     `json:"recoveryHeartRate"`
-	AvgGradeAdjustedSpeed          float64 `json:"avgGradeAdjustedSpeed"`
-	DifferenceBodyBattery          int     `json:"differenceBodyBattery"`
+	StartTimeLocal                 GarminLocalTime `json:"startTimeLocal"`
+	StartTimeGMT                   GarminGMTTime   `json:"startTimeGMT"`
+	StartLatitude                  float64         `json:"startLatitude"`
+	StartLongitude                 float64         `json:"startLongitude"`
+	Distance                       units.Distance  `json:"distance"`
+	Duration                       float64         `json:"duration"`
+	MovingDuration                 float64         `json:"movingDuration"`
+	ElapsedDuration                float64         `json:"elapsedDuration"`
+	ElevationGain                  units.Elevation `json:"elevationGain"`
+	ElevationLoss                  units.Elevation `json:"elevationLoss"`
+	MaxElevation                   units.Elevation `json:"maxElevation"`
+	MinElevation                   units.Elevation `json:"minElevation"`
+	AverageSpeed                   units.Speed     `json:"averageSpeed"`
+	AverageMovingSpeed             units.Speed     `json:"averageMovingSpeed"`
+	MaxSpeed                       units.Speed     `json:"maxSpeed"`
+	Calories                       float64         `json:"calories"`
+	BmrCalories                    float64         `json:"bmrCalories"`
+	AverageHR                      float64         `json:"averageHR"`
+	MaxHR                          float64         `json:"maxHR"`
+	AverageRunCadence              float64         `json:"averageRunCadence"`
+	MaxRunCadence                  float64         `json:"maxRunCadence"`
+	AveragePower                   float64         `json:"averagePower"`
+	MaxPower                       float64         `json:"maxPower"`
+	MinPower                       float64         `json:"minPower"`
+	NormalizedPower                float64         `json:"normalizedPower"`
+	TotalWork                      float64         `json:"totalWork"`
+	GroundContactTime              float64         `json:"groundContactTime"`
+	StrideLength                   float64         `json:"strideLength"`
+	VerticalOscillation            float64         `json:"verticalOscillation"`
+	TrainingEffect                 float64         `json:"trainingEffect"`
+	AnaerobicTrainingEffect        float64         `json:"anaerobicTrainingEffect"`
+	AerobicTrainingEffectMessage   string          `json:"aerobicTrainingEffectMessage"`
+	AnaerobicTrainingEffectMessage string          `json:"anaerobicTrainingEffectMessage"`
+	VerticalRatio                  float64         `json:"verticalRatio"`
+	EndLatitude                    float64         `json:"endLatitude"`
+	EndLongitude                   float64         `json:"endLongitude"`
+	MaxVerticalSpeed               float64         `json:"maxVerticalSpeed"`
+	WaterEstimated                 float64         `json:"waterEstimated"`
+	TrainingEffectLabel            string          `json:"trainingEffectLabel"`
+	ActivityTrainingLoad           float64         `json:"activityTrainingLoad"`
+	MinActivityLapDuration         float64         `json:"minActivityLapDuration"`
+	DirectWorkoutFeel              int             `json:"directWorkoutFeel"`
+	DirectWorkoutRpe               int             `json:"directWorkoutRpe"`
+	ModerateIntensityMinutes       int             `json:"moderateIntensityMinutes"`
+	VigorousIntensityMinutes       int             `json:"vigorousIntensityMinutes"`
+	Steps                          int             `json:"steps"`
+	RecoveryHeartRate              int             `json:"recoveryHeartRate"`
+	AvgGradeAdjustedSpeed          units.Speed     `json:"avgGradeAdjustedSpeed"`
+	DifferenceBodyBattery          int             `json:"differenceBodyBattery"`
+}
+
+// StartTime returns the start of the activity in the activity's own time zone.
+// The GMT timestamp is used when present, and is returned in UTC when the
+// activity has no usable time zone. Otherwise the local start time is resolved
+// with TimeZoneUnit.
+func (a *Activity) StartTime() (time.Time, error) {
+	loc, err := a.TimeZoneUnit.Location()
+	if gmt := a.SummaryDTO.StartTimeGMT; !gmt.IsZero() {
+		if err != nil {
+			return gmt.Time(), nil
+		}
+		return gmt.In(loc), nil
+	}
+	if err != nil {
+		return time.Time{}, err
+	}
+	return a.SummaryDTO.StartTimeLocal.In(loc), nil
 }
 
 func (as *ActivityService) Get(id int64) (*Activity, error) {
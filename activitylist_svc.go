@@ -3,67 +3,69 @@ package garmin
 import (
 	"net/url"
 	"strconv"
+
+	"github.com/jylitalo/go-garmin/units"
 )
 
 type ActivityListService service
 
 type ListedActivity struct {
-	ID                                    int64        `json:"activityId"`
-	Name                                  string       `json:"activityName"`
-	StartTimeLocal                        string       `json:"startTimeLocal"`
-	StartTimeGMT                          string       `json:"startTimeGMT"`
-	ActivityType                          ActivityType `json:"activityType"`
-	EventType                             EventType    `json:"eventType"`
-	Distance                              float64      `json:"distance"`
-	Duration                              float64      `json:"duration"`
-	ElapsedDuration                       float64      `json:"elapsedDuration"`
-	MovingDuration                        float64      `json:"movingDuration"`
-	ElevationGain                         float64      `json:"elevationGain"`
-	ElevationLoss                         float64      `json:"elevationLoss"`
-	AverageSpeed                          float64      `json:"averageSpeed"`
-	MaxSpeed                              float64      `json:"maxSpeed"`
-	StartLatitude                         float64      `json:"startLatitude"`
-	StartLongitude                        float64      `json:"startLongitude"`
-	HasPolyline                           bool         `json:"hasPolyline"`
-	HasImages                             bool         `json:"hasImages"`
-	OwnerID                               int          `json:"ownerId"`
-	OwnerDisplayName                      string       `json:"ownerDisplayName"`
-	OwnerFullName                         string       `json:"ownerFullName"`
-	OwnerProfileImageURLSmall             string       `json:"ownerProfileImageUrlSmall"`
-	OwnerProfileImageURLMedium            string       `json:"ownerProfileImageUrlMedium"`
-	OwnerProfileImageURLLarge             string       `json:"ownerProfileImageUrlLarge"`
-	Calories                              float64      `json:"calories"`
-	BmrCalories                           float64      `json:"bmrCalories"`
-	AverageHR                             float64      `json:"averageHR"`
-	MaxHR                                 float64      `json:"maxHR"`
-	AverageRunningCadenceInStepsPerMinute float64      `json:"averageRunningCadenceInStepsPerMinute"`
-	MaxRunningCadenceInStepsPerMinute     float64      `json:"maxRunningCadenceInStepsPerMinute"`
-	Steps                                 int          `json:"steps"`
-	UserRoles                             []string     `json:"userRoles"`
+	ID                                    int64           `json:"activityId"`
+	Name                                  string          `json:"activityName"`
+	StartTimeLocal                        GarminLocalTime `json:"startTimeLocal"`
+	StartTimeGMT                          GarminGMTTime   `json:"startTimeGMT"`
+	ActivityType                          ActivityType    `json:"activityType"`
+	EventType                             EventType       `json:"eventType"`
+	Distance                              units.Distance  `json:"distance"`
+	Duration                              float64         `json:"duration"`
+	ElapsedDuration                       float64         `json:"elapsedDuration"`
+	MovingDuration                        float64         `json:"movingDuration"`
+	ElevationGain                         units.Elevation `json:"elevationGain"`
+	ElevationLoss                         units.Elevation `json:"elevationLoss"`
+	AverageSpeed                          units.Speed     `json:"averageSpeed"`
+	MaxSpeed                              units.Speed     `json:"maxSpeed"`
+	StartLatitude                         float64         `json:"startLatitude"`
+	StartLongitude                        float64         `json:"startLongitude"`
+	HasPolyline                           bool            `json:"hasPolyline"`
+	HasImages                             bool            `json:"hasImages"`
+	OwnerID                               int             `json:"ownerId"`
+	OwnerDisplayName                      string          `json:"ownerDisplayName"`
+	OwnerFullName                         string          `json:"ownerFullName"`
+	OwnerProfileImageURLSmall             string          `json:"ownerProfileImageUrlSmall"`
+	OwnerProfileImageURLMedium            string          `json:"ownerProfileImageUrlMedium"`
+	OwnerProfileImageURLLarge             string          `json:"ownerProfileImageUrlLarge"`
+	Calories                              float64         `json:"calories"`
+	BmrCalories                           float64         `json:"bmrCalories"`
+	AverageHR                             float64         `json:"averageHR"`
+	MaxHR                                 float64         `json:"maxHR"`
+	AverageRunningCadenceInStepsPerMinute float64         `json:"averageRunningCadenceInStepsPerMinute"`
+	MaxRunningCadenceInStepsPerMinute     float64         `json:"maxRunningCadenceInStepsPerMinute"`
+	Steps                                 int             `json:"steps"`
+	UserRoles                             []string        `json:"userRoles"`
 	Privacy                               struct {
 		TypeID  int    `json:"typeId"`
 		TypeKey string `json:"typeKey"`
 	} `json:"privacy"`
-	UserPro                 bool    `json:"userPro"`
-	CourseID                int     `json:"courseId,omitempty"`
-	HasVideo                bool    `json:"hasVideo"`
-	TimeZoneID              int     `json:"timeZoneId"`
-	BeginTimestamp          int64   `json:"beginTimestamp"`
-	SportTypeID             int     `json:"sportTypeId"`
-	AvgPower                float64 `json:"avgPower"`
-	MaxPower                float64 `json:"maxPower"`
-	AerobicTrainingEffect   float64 `json:"aerobicTrainingEffect"`
-	AnaerobicTrainingEffect float64 `json:"anaerobicTrainingEffect"`
-	NormPower               float64 `json:"normPower"`
-	AvgVerticalOscillation  float64 `json:"avgVerticalOscillation"`
-	AvgGroundContactTime    float64 `json:"avgGroundContactTime"`
-	AvgStrideLength         float64 `json:"avgStrideLength"`
-	VO2MaxValue             float64 `json:"vO2MaxValue"`
-	AvgVerticalRatio        float64 `json:"avgVerticalRatio"`
-	DeviceID                int64   `json:"deviceId"`
-	MinElevation            float64 `json:"minElevation"`
-	MaxElevation            float64 `json:"maxElevation"`
-	MaxDoubleCadence        float64 `json:"maxDoubleCadence"`
+	UserPro                 bool            `json:"userPro"`
+	CourseID                int             `json:"courseId,omitempty"`
+	HasVideo                bool            `json:"hasVideo"`
+	TimeZoneID              int             `json:"timeZoneId"`
+	BeginTimestamp          int64           `json:"beginTimestamp"`
+	SportTypeID             int             `json:"sportTypeId"`
+	AvgPower                float64         `json:"avgPower"`
+	MaxPower                float64         `json:"maxPower"`
+	AerobicTrainingEffect   float64         `json:"aerobicTrainingEffect"`
+	AnaerobicTrainingEffect float64         `json:"anaerobicTrainingEffect"`
+	NormPower               float64         `json:"normPower"`
+	AvgVerticalOscillation  float64         `json:"avgVerticalOscillation"`
+	AvgGroundContactTime    float64         `json:"avgGroundContactTime"`
+	AvgStrideLength         float64         `json:"avgStrideLength"`
+	VO2MaxValue             float64         `json:"vO2MaxValue"`
+	AvgVerticalRatio        float64         `json:"avgVerticalRatio"`
+	DeviceID                int64           `json:"deviceId"`
+	MinElevation            units.Elevation `json:"minElevation"`
+	MaxElevation            units.Elevation `json:"maxElevation"`
+	MaxDoubleCadence        float64         `json:"maxDoubleCadence"`
 	SummarizedDiveInfo      struct {
 		SummarizedDiveGases []any `json:"summarizedDiveGases"`
 	} `json:"summarizedDiveInfo"`
@@ -83,7 +85,7 @@ type ListedActivity struct {
 	HasSplits                      bool                         `json:"hasSplits"`
 	ModerateIntensityMinutes       int                          `json:"moderateIntensityMinutes"`
 	VigorousIntensityMinutes       int                          `json:"vigorousIntensityMinutes"`
-	AvgGradeAdjustedSpeed          float64                      `json:"avgGradeAdjustedSpeed"`
+	AvgGradeAdjustedSpeed          units.Speed                  `json:"avgGradeAdjustedSpeed"`
 	DifferenceBodyBattery          int                          `json:"differenceBodyBattery"`
 	Pr                             bool                         `json:"pr"`
 	AutoCalcCalories               bool                         `json:"autoCalcCalories"`
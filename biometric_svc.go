@@ -0,0 +1,208 @@
+package garmin
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jylitalo/go-garmin/units"
+)
+
+// BiometricService has the user's thresholds and training zones.
+type BiometricService service
+
+// ltSpeedScale converts Garmin's lactate threshold speed to m/s, Garmin keeps
+// it in tens of meters per second.
+const ltSpeedScale = 10
+
+// ThresholdSpeed is a lactate threshold speed as Garmin stores it.
+type ThresholdSpeed float64
+
+// Speed returns the threshold speed in m/s.
+func (ts ThresholdSpeed) Speed() units.Speed {
+	return units.Speed(float64(ts)*ltSpeedScale) * units.MeterPerSecond
+}
+
+// ThresholdSpeedOf converts a speed to the form Garmin stores.
+func ThresholdSpeedOf(s units.Speed) ThresholdSpeed {
+	return ThresholdSpeed(s.MetersPerSecond() / ltSpeedScale)
+}
+
+type ThresholdMeasurement struct {
+	UserProfilePK            int64          `json:"userProfilePK"`
+	Version                  int64          `json:"version"`
+	CalendarDate             GarminGMTTime  `json:"calendarDate"`
+	Sequence                 int64          `json:"sequence"`
+	Speed                    ThresholdSpeed `json:"speed"`
+	HeartRate                *int           `json:"heartRate"`
+	HeartRateCycling         *int           `json:"heartRateCycling"`
+	FunctionalThresholdPower *int           `json:"functionalThresholdPower"`
+	PowerToWeight            *float64       `json:"powerToWeight"`
+	// Weight in kilograms.
+	Weight  *float64 `json:"weight"`
+	IsStale bool     `json:"isStale"`
+}
+
+type LactateThreshold struct {
+	SpeedAndHeartRate *ThresholdMeasurement `json:"speed_and_heart_rate"`
+	Power             *ThresholdMeasurement `json:"power"`
+}
+
+// LactateThreshold returns the latest lactate threshold heart rate, speed and
+// power.
+func (bs *BiometricService) LactateThreshold() (*LactateThreshold, error) {
+	// GET https://connect.garmin.com/biometric-service/biometric/latestLactateThreshold
+	var lt LactateThreshold
+	return &lt, bs.c.apiGet(&lt, "/biometric-service/biometric/latestLactateThreshold", nil)
+}
+
+// Sport of a training zone definition.
+type ZoneSport string
+
+const (
+	ZoneSportDefault  ZoneSport = "DEFAULT"
+	ZoneSportRunning  ZoneSport = "RUNNING"
+	ZoneSportCycling  ZoneSport = "CYCLING"
+	ZoneSportSwimming ZoneSport = "SWIMMING"
+)
+
+// FunctionalThresholdPower returns the latest FTP for the sport.
+func (bs *BiometricService) FunctionalThresholdPower(sport ZoneSport) (*ThresholdMeasurement, error) {
+	// GET https://connect.garmin.com/biometric-service/biometric/latestFunctionalThresholdPower/CYCLING
+	var tm ThresholdMeasurement
+	p := fmt.Sprintf("/biometric-service/biometric/latestFunctionalThresholdPower/%s", sport)
+	return &tm, bs.c.apiGet(&tm, p, nil)
+}
+
+// Zone is one training zone, High is zero for an open ended top zone.
+type Zone struct {
+	Number int
+	Low    int
+	High   int
+}
+
+// zoneOf returns the number of the zone of value, zero when it is below the
+// first zone.
+func zoneOf(zones []Zone, value int) int {
+	n := 0
+	for _, z := range zones {
+		if value >= z.Low {
+			n = z.Number
+		}
+	}
+	return n
+}
+
+type HRTrainingMethod string
+
+const (
+	HRMethodMaxHR            HRTrainingMethod = "PERCENT_MAX_HR"
+	HRMethodReserve          HRTrainingMethod = "HR_RESERVE"
+	HRMethodLactateThreshold HRTrainingMethod = "LACTATE_THRESHOLD"
+)
+
+// HeartRateZones is the heart rate zone definition of a sport in bpm.
+type HeartRateZones struct {
+	Sport                         ZoneSport        `json:"sport"`
+	TrainingMethod                HRTrainingMethod `json:"trainingMethod"`
+	RestingHeartRateUsed          int              `json:"restingHeartRateUsed"`
+	LactateThresholdHeartRateUsed *int             `json:"lactateThresholdHeartRateUsed"`
+	MaxHeartRateUsed              int              `json:"maxHeartRateUsed"`
+	RestingHRAutoUpdateUsed       bool             `json:"restingHrAutoUpdateUsed"`
+	Zone1Floor                    int              `json:"zone1Floor"`
+	Zone2Floor                    int              `json:"zone2Floor"`
+	Zone3Floor                    int              `json:"zone3Floor"`
+	Zone4Floor                    int              `json:"zone4Floor"`
+	Zone5Floor                    int              `json:"zone5Floor"`
+	ChangeState                   string           `json:"changeState,omitempty"`
+}
+
+// Zones returns the zone boundaries, the top zone ends at the max heart rate.
+func (hz *HeartRateZones) Zones() []Zone {
+	floors := []int{hz.Zone1Floor, hz.Zone2Floor, hz.Zone3Floor, hz.Zone4Floor, hz.Zone5Floor}
+	zones := make([]Zone, len(floors))
+	for i, f := range floors {
+		zones[i] = Zone{Number: i + 1, Low: f, High: hz.MaxHeartRateUsed}
+		if i+1 < len(floors) {
+			zones[i].High = floors[i+1] - 1
+		}
+	}
+	return zones
+}
+
+// Zone returns the zone of the heart rate, zero when it is below zone 1.
+func (hz *HeartRateZones) Zone(bpm int) int { return zoneOf(hz.Zones(), bpm) }
+
+// HeartRateZones returns the heart rate zones of every sport that has its own,
+// and the DEFAULT zones used for the rest.
+func (bs *BiometricService) HeartRateZones() (res []HeartRateZones, err error) {
+	// GET https://connect.garmin.com/biometric-service/heartRateZones
+	return res, bs.c.apiGet(&res, "/biometric-service/heartRateZones", nil)
+}
+
+// UpdateHeartRateZones replaces the zones of the given sports. Max and
+// resting heart rate are updated through MaxHeartRateUsed and
+// RestingHeartRateUsed.
+func (bs *BiometricService) UpdateHeartRateZones(zones []HeartRateZones) error {
+	// PUT https://connect.garmin.com/biometric-service/heartRateZones
+	return bs.c.apiSend(nil, "PUT", "/biometric-service/heartRateZones", zones)
+}
+
+// PowerZones is the power zone definition of a sport in watts.
+type PowerZones struct {
+	Sport                    ZoneSport       `json:"sport"`
+	FunctionalThresholdPower int             `json:"functionalThresholdPower"`
+	Zone1Floor               int             `json:"zone1Floor"`
+	Zone2Floor               int             `json:"zone2Floor"`
+	Zone3Floor               int             `json:"zone3Floor"`
+	Zone4Floor               int             `json:"zone4Floor"`
+	Zone5Floor               int             `json:"zone5Floor"`
+	Zone6Floor               int             `json:"zone6Floor"`
+	Zone7Floor               int             `json:"zone7Floor"`
+	UserLocalTime            GarminLocalTime `json:"userLocalTime"`
+	ChangeState              string          `json:"changeState,omitempty"`
+}
+
+// Zones returns the zone boundaries, the top zone is open ended.
+func (pz *PowerZones) Zones() []Zone {
+	floors := []int{pz.Zone1Floor, pz.Zone2Floor, pz.Zone3Floor, pz.Zone4Floor, pz.Zone5Floor, pz.Zone6Floor, pz.Zone7Floor}
+	zones := make([]Zone, len(floors))
+	for i, f := range floors {
+		zones[i] = Zone{Number: i + 1, Low: f}
+		if i+1 < len(floors) {
+			zones[i].High = floors[i+1] - 1
+		}
+	}
+	return zones
+}
+
+// Zone returns the zone of the power, zero when it is below zone 1.
+func (pz *PowerZones) Zone(watts int) int { return zoneOf(pz.Zones(), watts) }
+
+func (bs *BiometricService) PowerZones() (res []PowerZones, err error) {
+	// GET https://connect.garmin.com/biometric-service/powerZones/sports/all
+	return res, bs.c.apiGet(&res, "/biometric-service/powerZones/sports/all", nil)
+}
+
+// UpdatePowerZones replaces the zones of the given sports, the FTP is updated
+// through FunctionalThresholdPower.
+func (bs *BiometricService) UpdatePowerZones(zones []PowerZones) error {
+	// PUT https://connect.garmin.com/biometric-service/powerZones
+	return bs.c.apiSend(nil, "PUT", "/biometric-service/powerZones", zones)
+}
+
+// UpdateLactateThreshold sets the lactate threshold heart rate and speed,
+// the same way entering them in the user settings does.
+func (bs *BiometricService) UpdateLactateThreshold(heartRate int, speed units.Speed) error {
+	return (*UserProfileService)(bs).UpdateSettings(new(UserSettingsUpdate).LactateThreshold(heartRate, speed))
+}
+
+// TimeInZones adds up the time spent in each zone from samples taken at the
+// given times, for example heart rate values from ActivityDetails. A sample
+// counts until the next one. Index 0 is the time below zone 1.
+func TimeInZones(zones []Zone, times []time.Time, values []int) []time.Duration {
+	res := make([]time.Duration, len(zones)+1)
+	for i := 0; i+1 < len(times) && i+1 < len(values); i++ {
+		res[zoneOf(zones, values[i])] += times[i+1].Sub(times[i])
+	}
+	return res
+}
@@ -0,0 +1,138 @@
+package garmin
+
+import (
+	"encoding/json"
+	"io"
+	"math"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/jylitalo/go-garmin/units"
+)
+
+func TestBiometric(t *testing.T) {
+	var settings []byte
+	transport := &fakeTransport{fn: func(req *http.Request) (*http.Response, error) {
+		switch req.URL.Path {
+		case "/biometric-service/biometric/latestLactateThreshold":
+			return jsonResponse(req, http.StatusOK, `{
+				"speed_and_heart_rate": {"userProfilePK": 1, "calendarDate": "2024-08-16T06:00:00.0", "speed": 0.3846, "heartRate": 168},
+				"power": {"userProfilePK": 1, "calendarDate": "2024-08-16T06:00:00.0", "functionalThresholdPower": 290, "powerToWeight": 3.8, "weight": 76.3}
+			}`), nil
+		case "/biometric-service/heartRateZones":
+			return jsonResponse(req, http.StatusOK, `[{
+				"sport": "DEFAULT", "trainingMethod": "HR_RESERVE", "restingHeartRateUsed": 48, "maxHeartRateUsed": 190,
+				"zone1Floor": 119, "zone2Floor": 133, "zone3Floor": 147, "zone4Floor": 161, "zone5Floor": 176
+			}]`), nil
+		case "/biometric-service/powerZones/sports/all":
+			return jsonResponse(req, http.StatusOK, `[{
+				"sport": "CYCLING", "functionalThresholdPower": 290,
+				"zone1Floor": 0, "zone2Floor": 160, "zone3Floor": 218, "zone4Floor": 261, "zone5Floor": 305, "zone6Floor": 348, "zone7Floor": 435
+			}]`), nil
+		case "/userprofile-service/userprofile/user-settings":
+			settings, _ = io.ReadAll(req.Body)
+			return jsonResponse(req, http.StatusNoContent, ``), nil
+		}
+		return jsonResponse(req, http.StatusNotFound, `{}`), nil
+	}}
+	api := NewAPI(NewClient(WithTransport(transport)))
+
+	lt, err := api.Biometric.LactateThreshold()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hr := lt.SpeedAndHeartRate.HeartRate; hr == nil || *hr != 168 {
+		t.Errorf("heart rate: %v", hr)
+	}
+	if s := lt.SpeedAndHeartRate.Speed.Speed().MetersPerSecond(); math.Abs(s-3.846) > 1e-9 {
+		t.Errorf("speed: %v", s)
+	}
+	if ftp := lt.Power.FunctionalThresholdPower; ftp == nil || *ftp != 290 {
+		t.Errorf("ftp: %v", ftp)
+	}
+
+	hrz, err := api.Biometric.HeartRateZones()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(hrz) != 1 {
+		t.Fatalf("heart rate zones: %+v", hrz)
+	}
+	if z := hrz[0].Zones(); z[0] != (Zone{1, 119, 132}) || z[4] != (Zone{5, 176, 190}) {
+		t.Errorf("zones: %+v", z)
+	}
+	for bpm, exp := range map[int]int{100: 0, 119: 1, 146: 2, 147: 3, 200: 5} {
+		if z := hrz[0].Zone(bpm); z != exp {
+			t.Errorf("%d bpm: got zone %d, want %d", bpm, z, exp)
+		}
+	}
+
+	pz, err := api.Biometric.PowerZones()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pz) != 1 || pz[0].Zone(300) != 4 || pz[0].Zones()[6].High != 0 {
+		t.Errorf("power zones: %+v", pz)
+	}
+
+	start := time.Date(2024, 8, 16, 6, 0, 0, 0, time.UTC)
+	times := []time.Time{start, start.Add(time.Minute), start.Add(3 * time.Minute), start.Add(4 * time.Minute)}
+	tiz := TimeInZones(hrz[0].Zones(), times, []int{110, 150, 165, 170})
+	if tiz[0] != time.Minute || tiz[3] != 2*time.Minute || tiz[4] != time.Minute {
+		t.Errorf("time in zones: %v", tiz)
+	}
+
+	if err = api.Biometric.UpdateLactateThreshold(170, 4*units.MeterPerSecond); err != nil {
+		t.Fatal(err)
+	}
+	var usu UserSettingsUpdate
+	if err = json.Unmarshal(settings, &usu); err != nil {
+		t.Fatalf("%s: %v", settings, err)
+	}
+	if d := usu.UserData; *d.LactateThresholdHeartRate != 170 || *d.LactateThresholdSpeed != 0.4 {
+		t.Errorf("settings: %s", settings)
+	}
+}
+
+func TestBiometricRequests(t *testing.T) {
+	testRequests(t, []requestTest{
+		{
+			name: "UpdateHeartRateZones",
+			call: func(api *API) error {
+				return api.Biometric.UpdateHeartRateZones([]HeartRateZones{{
+					Sport: ZoneSportRunning, TrainingMethod: HRMethodReserve, RestingHeartRateUsed: 48, MaxHeartRateUsed: 190,
+					Zone1Floor: 119, Zone2Floor: 133, Zone3Floor: 147, Zone4Floor: 161, Zone5Floor: 176, ChangeState: "CHANGED",
+				}})
+			},
+			method: "PUT",
+			path:   "/biometric-service/heartRateZones",
+			body: `[{"sport": "RUNNING", "trainingMethod": "HR_RESERVE", "restingHeartRateUsed": 48,
+				"lactateThresholdHeartRateUsed": null, "maxHeartRateUsed": 190, "restingHrAutoUpdateUsed": false,
+				"zone1Floor": 119, "zone2Floor": 133, "zone3Floor": 147, "zone4Floor": 161, "zone5Floor": 176,
+				"changeState": "CHANGED"}]`,
+		},
+		{
+			name: "UpdatePowerZones",
+			call: func(api *API) error {
+				return api.Biometric.UpdatePowerZones([]PowerZones{{
+					Sport: ZoneSportCycling, FunctionalThresholdPower: 290,
+					Zone2Floor: 160, Zone3Floor: 218, Zone4Floor: 261, Zone5Floor: 305, Zone6Floor: 348, Zone7Floor: 435,
+					UserLocalTime: GarminLocalTime(requestTestTime),
+				}})
+			},
+			method: "PUT",
+			path:   "/biometric-service/powerZones",
+			body: `[{"sport": "CYCLING", "functionalThresholdPower": 290, "zone1Floor": 0, "zone2Floor": 160,
+				"zone3Floor": 218, "zone4Floor": 261, "zone5Floor": 305, "zone6Floor": 348, "zone7Floor": 435,
+				"userLocalTime": "2024-08-16T07:30:00.0"}]`,
+		},
+		{
+			name:   "UpdateLactateThreshold",
+			call:   func(api *API) error { return api.Biometric.UpdateLactateThreshold(170, 4*units.MeterPerSecond) },
+			method: "PUT",
+			path:   "/userprofile-service/userprofile/user-settings",
+			body:   `{"userData": {"lactateThresholdHeartRate": 170, "lactateThresholdSpeed": 0.4}}`,
+		},
+	})
+}
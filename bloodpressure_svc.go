@@ -0,0 +1,99 @@
+package garmin
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+)
+
+type BloodPressureService service
+
+// BloodPressure is one reading, pressures are in mmHg and pulse in bpm.
+type BloodPressure struct {
+	UserProfilePk             int64           `json:"userProfilePk"`
+	Version                   int64           `json:"version"`
+	Systolic                  int             `json:"systolic"`
+	Diastolic                 int             `json:"diastolic"`
+	Pulse                     *int            `json:"pulse"`
+	Notes                     *string         `json:"notes"`
+	SourceType                string          `json:"sourceType"`
+	Category                  *int            `json:"category"`
+	CategoryName              *string         `json:"categoryName"`
+	MultiMeasurement          bool            `json:"multiMeasurement"`
+	MeasurementTimestampLocal GarminLocalTime `json:"measurementTimestampLocal"`
+	MeasurementTimestampGMT   GarminGMTTime   `json:"measurementTimestampGMT"`
+}
+
+// BloodPressureDay has the readings of one day.
+type BloodPressureDay struct {
+	StartDate         CalendarDate    `json:"startDate"`
+	EndDate           CalendarDate    `json:"endDate"`
+	Measurements      []BloodPressure `json:"measurements"`
+	HighSystolic      *int            `json:"highSystolic"`
+	LowSystolic       *int            `json:"lowSystolic"`
+	HighDiastolic     *int            `json:"highDiastolic"`
+	LowDiastolic      *int            `json:"lowDiastolic"`
+	NumOfMeasurements int             `json:"numOfMeasurements"`
+	AverageSystolic   *float64        `json:"averageSystolic"`
+	AverageDiastolic  *float64        `json:"averageDiastolic"`
+	CategoryStats     any             `json:"categoryStats"`
+}
+
+type BloodPressureRange struct {
+	From                 CalendarDate       `json:"from"`
+	Until                CalendarDate       `json:"until"`
+	MeasurementSummaries []BloodPressureDay `json:"measurementSummaries"`
+}
+
+// Readings returns all readings of the range, oldest first as Garmin sends
+// them.
+func (bpr *BloodPressureRange) Readings() []BloodPressure {
+	var res []BloodPressure
+	for _, d := range bpr.MeasurementSummaries {
+		res = append(res, d.Measurements...)
+	}
+	return res
+}
+
+// Range returns the readings from start to end, both dates included.
+func (bps *BloodPressureService) Range(start, end time.Time) (*BloodPressureRange, error) {
+	// GET https://connect.garmin.com/bloodpressure-service/bloodpressure/range/2024-08-01/2024-08-16?includeAll=true
+	var bpr BloodPressureRange
+	p := datepath("/bloodpressure-service/bloodpressure/range", start, end)
+	return &bpr, bps.c.apiGet(&bpr, p, url.Values{"includeAll": {"true"}})
+}
+
+// Add logs a manual reading taken at the given time, pulse and notes are
+// left out when zero. The location of `at` decides the local time of the
+// reading.
+func (bps *BloodPressureService) Add(systolic, diastolic, pulse int, notes string, at time.Time) error {
+	// POST https://connect.garmin.com/bloodpressure-service/bloodpressure
+	//
+	// {"measurementTimestampLocal":"2024-08-16T07:30:00.00","measurementTimestampGMT":"2024-08-16T14:30:00.00","systolic":120,"diastolic":80,"pulse":60,"sourceType":"MANUAL","notes":""}
+	const dateFormat = "2006-01-02T15:04:05.00"
+	payload := struct {
+		Local     string `json:"measurementTimestampLocal"`
+		GMT       string `json:"measurementTimestampGMT"`
+		Systolic  int    `json:"systolic"`
+		Diastolic int    `json:"diastolic"`
+		Pulse     int    `json:"pulse,omitempty"`
+		Source    string `json:"sourceType"`
+		Notes     string `json:"notes,omitempty"`
+	}{
+		Local:     at.Format(dateFormat),
+		GMT:       at.UTC().Format(dateFormat),
+		Systolic:  systolic,
+		Diastolic: diastolic,
+		Pulse:     pulse,
+		Source:    "MANUAL",
+		Notes:     notes,
+	}
+	return bps.c.apiSend(nil, "POST", "/bloodpressure-service/bloodpressure", &payload)
+}
+
+// Delete removes the reading with the version taken on the local date.
+func (bps *BloodPressureService) Delete(date time.Time, version int64) error {
+	// DELETE https://connect.garmin.com/bloodpressure-service/bloodpressure/2024-08-16/1723818600000
+	p := fmt.Sprintf("/bloodpressure-service/bloodpressure/%s/%d", date.Format(time.DateOnly), version)
+	return bps.c.apiSend(nil, "DELETE", p, nil)
+}
@@ -0,0 +1,88 @@
+package garmin
+
+import (
+	"io"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestBloodPressure(t *testing.T) {
+	var requests []string
+	transport := &fakeTransport{fn: func(req *http.Request) (*http.Response, error) {
+		var body []byte
+		if req.Body != nil {
+			body, _ = io.ReadAll(req.Body)
+		}
+		requests = append(requests, req.Method+" "+req.URL.Path+" "+string(body))
+		switch req.Method + " " + req.URL.Path {
+		case "GET /bloodpressure-service/bloodpressure/range/2024-08-15/2024-08-16":
+			return jsonResponse(req, http.StatusOK, `{"from": "2024-08-15", "until": "2024-08-16", "measurementSummaries": [
+				{"startDate": "2024-08-15", "endDate": "2024-08-15", "numOfMeasurements": 1, "measurements": [
+					{"version": 1723705200000, "systolic": 118, "diastolic": 76, "pulse": 58, "notes": null, "sourceType": "INDEX_BPM",
+					 "measurementTimestampLocal": "2024-08-15T00:00:00.0", "measurementTimestampGMT": "2024-08-15T07:00:00.0"}]},
+				{"startDate": "2024-08-16", "endDate": "2024-08-16", "numOfMeasurements": 1, "measurements": [
+					{"version": 1723818600000, "systolic": 125, "diastolic": 82, "pulse": null, "notes": "after coffee", "sourceType": "MANUAL",
+					 "measurementTimestampLocal": "2024-08-16T07:30:00.0", "measurementTimestampGMT": "2024-08-16T14:30:00.0"}]}
+			]}`), nil
+		case "POST /bloodpressure-service/bloodpressure":
+			return jsonResponse(req, http.StatusOK, `{}`), nil
+		case "DELETE /bloodpressure-service/bloodpressure/2024-08-16/1723818600000":
+			return jsonResponse(req, http.StatusNoContent, ``), nil
+		}
+		return jsonResponse(req, http.StatusNotFound, `{}`), nil
+	}}
+	api := NewAPI(NewClient(WithTransport(transport)))
+	start, end := time.Date(2024, 8, 15, 0, 0, 0, 0, time.UTC), time.Date(2024, 8, 16, 0, 0, 0, 0, time.UTC)
+
+	bpr, err := api.BloodPressure.Range(start, end)
+	if err != nil {
+		t.Fatal(err)
+	}
+	readings := bpr.Readings()
+	if len(readings) != 2 || readings[0].SourceType != "INDEX_BPM" || *readings[0].Pulse != 58 {
+		t.Fatalf("readings: %+v", readings)
+	}
+	if r := readings[1]; r.Pulse != nil || *r.Notes != "after coffee" || r.MeasurementTimestampGMT.Time().Hour() != 14 {
+		t.Errorf("reading: %+v", r)
+	}
+
+	loc := time.FixedZone("UTC-7", -7*60*60)
+	if err = api.BloodPressure.Add(125, 82, 0, "after coffee", time.Date(2024, 8, 16, 7, 30, 0, 0, loc)); err != nil {
+		t.Fatal(err)
+	}
+	exp := `POST /bloodpressure-service/bloodpressure {"measurementTimestampLocal":"2024-08-16T07:30:00.00",` +
+		`"measurementTimestampGMT":"2024-08-16T14:30:00.00","systolic":125,"diastolic":82,"sourceType":"MANUAL","notes":"after coffee"}` + "\n"
+	if requests[1] != exp {
+		t.Errorf("got %s", requests[1])
+	}
+	if err = api.BloodPressure.Delete(end, readings[1].Version); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestBloodPressureRequests(t *testing.T) {
+	loc := time.FixedZone("UTC-7", -7*60*60)
+	testRequests(t, []requestTest{
+		{
+			name: "Add",
+			call: func(api *API) error {
+				return api.BloodPressure.Add(125, 82, 61, "", time.Date(2024, 8, 16, 21, 15, 0, 0, loc))
+			},
+			response: `{}`,
+			method:   "POST",
+			path:     "/bloodpressure-service/bloodpressure",
+			// The GMT timestamp is on the next day.
+			body: `{"measurementTimestampLocal": "2024-08-16T21:15:00.00", "measurementTimestampGMT": "2024-08-17T04:15:00.00",
+				"systolic": 125, "diastolic": 82, "pulse": 61, "sourceType": "MANUAL"}`,
+		},
+		{
+			name: "Delete",
+			call: func(api *API) error {
+				return api.BloodPressure.Delete(time.Date(2024, 8, 16, 21, 15, 0, 0, loc), 1723868100000)
+			},
+			method: "DELETE",
+			path:   "/bloodpressure-service/bloodpressure/2024-08-16/1723868100000",
+		},
+	})
+}
@@ -0,0 +1,95 @@
+package garmin
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"github.com/jylitalo/go-garmin/internal/rt"
+)
+
+type (
+	// Cache stores responses for WithCache, see NewMemoryCache and
+	// NewDirCache.
+	Cache     = rt.Cache
+	CacheRule = rt.CacheRule
+)
+
+// Forever is a CacheRule TTL for responses that never change.
+const Forever = rt.Forever
+
+// DefaultCacheSize is the size in bytes that caches are kept under when none
+// is given.
+const DefaultCacheSize = rt.DefaultCacheSize
+
+// NewMemoryCache keeps up to maxBytes of cached responses in memory, or
+// DefaultCacheSize when maxBytes is zero. The least recently used responses
+// are dropped first.
+func NewMemoryCache(maxBytes int64) Cache { return rt.NewMemoryCache(maxBytes) }
+
+// NewDirCache keeps cached responses as files in dir, so they survive
+// restarts. Like NewMemoryCache, the least recently used files are removed
+// once they take more than maxBytes.
+func NewDirCache(dir string, maxBytes int64) (Cache, error) { return rt.NewDirCache(dir, maxBytes) }
+
+var (
+	// FixedTTL caches every response for ttl.
+	FixedTTL = rt.FixedTTL
+	// ForeverBefore caches responses Forever when the latest date in the
+	// request is more than days before today, and for ttl otherwise.
+	ForeverBefore = rt.ForeverBefore
+)
+
+// DefaultCacheRules cache daily data for ten minutes, and forever once the day
+// is more than two days old. Activity data is cached for a day. Patterns use
+// the placeholders of the endpoint templates, see LookupEndpoint.
+var DefaultCacheRules = []CacheRule{
+	{Pattern: "/sleep-service/sleep/dailySleepData", TTL: ForeverBefore(2, 10*time.Minute)},
+	{Pattern: "/wellness-service/wellness/dailySleepData/{displayName}", TTL: ForeverBefore(2, 10*time.Minute)},
+	{Pattern: "/wellness-service/wellness/dailyStress/{date}", TTL: ForeverBefore(2, 10*time.Minute)},
+	{Pattern: "/wellness-service/wellness/bodyBattery/events/{date}", TTL: ForeverBefore(2, 10*time.Minute)},
+	{Pattern: "/wellness-service/wellness/daily/*/{date}", TTL: ForeverBefore(2, 10*time.Minute)},
+	{Pattern: "/wellness-service/wellness/floorsChartData/daily/{date}", TTL: ForeverBefore(2, 10*time.Minute)},
+	{Pattern: "/wellness-service/wellness/dailyEvents/{userUUID}", TTL: ForeverBefore(2, 10*time.Minute)},
+	{Pattern: "/usersummary-service/stats/*/*/{start}/{end}", TTL: ForeverBefore(2, 10*time.Minute)},
+	{Pattern: "/usersummary-service/stats/*/weekly/{date}/{weeks}", TTL: ForeverBefore(2, 10*time.Minute)},
+	{Pattern: "/usersummary-service/stats/steps/monthly/{date}/{months}", TTL: ForeverBefore(2, 10*time.Minute)},
+	{Pattern: "/sleep-service/stats/sleep/daily/{start}/{end}", TTL: ForeverBefore(2, 10*time.Minute)},
+	{Pattern: "/sleep-service/stats/sleep/weekly/{date}/{weeks}", TTL: ForeverBefore(2, 10*time.Minute)},
+	{Pattern: "/fitnessage-service/fitnessage/{date}", TTL: ForeverBefore(2, 10*time.Minute)},
+	{Pattern: "/metrics-service/metrics/*/daily/{start}/{end}", TTL: ForeverBefore(2, 10*time.Minute)},
+	{Pattern: "/hrv-service/hrv/{date}", TTL: ForeverBefore(2, 10*time.Minute)},
+	{Pattern: "/hrv-service/hrv/daily/{start}/{end}", TTL: ForeverBefore(2, 10*time.Minute)},
+	{Pattern: "/activity-service/activity/{id}", TTL: FixedTTL(24 * time.Hour)},
+	{Pattern: "/activity-service/activity/{id}/*", TTL: FixedTTL(24 * time.Hour)},
+}
+
+// WithCache caches GET responses for endpoints matching the rules, the first
+// matching rule is used. DefaultCacheRules are used when no rules are given.
+// Cached responses are served without counting as requests for WithObserver.
+//
+// Responses are cached per account, so one cache can be shared by clients of
+// different accounts.
+func WithCache(cache Cache, rules ...CacheRule) ClientOpt {
+	if len(rules) == 0 {
+		rules = DefaultCacheRules
+	}
+	return func(co *clientOpts) {
+		co.Cache = &rt.Caching{Cache: cache, Rules: rules}
+	}
+}
+
+// setCacheScope keeps the responses cached for the account logged in with
+// basic apart from those of other accounts. A nil token is for requests made
+// without logging in.
+func (c *Client) setCacheScope(basic *OAuth1Token) {
+	if c.cache == nil {
+		return
+	}
+	var scope string
+	if basic != nil {
+		sum := sha256.Sum256([]byte(basic.Token))
+		scope = hex.EncodeToString(sum[:16])
+	}
+	c.cache.SetScope(scope)
+}
@@ -0,0 +1,230 @@
+package garmin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"path"
+	"testing"
+	"time"
+)
+
+func TestCache(t *testing.T) {
+	now := time.Date(2024, 8, 16, 12, 0, 0, 0, time.UTC)
+	clock := fakeClock(now)
+	var requests []*http.Request
+	transport := &fakeTransport{fn: func(req *http.Request) (*http.Response, error) {
+		requests = append(requests, req)
+		if req.Header.Get("If-None-Match") == `"v1"` {
+			return jsonResponse(req, http.StatusNotModified, ""), nil
+		}
+		res := jsonResponse(req, http.StatusOK, `{"calendarDate": "`+req.URL.Query().Get("date")+`"}`)
+		res.Header.Set("ETag", `"v1"`)
+		return res, nil
+	}}
+	dir, err := NewDirCache(t.TempDir(), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, cache := range map[string]Cache{"Memory": NewMemoryCache(0), "Dir": dir} {
+		t.Run(name, func(t *testing.T) {
+			requests, clock = nil, fakeClock(now)
+			client := NewClient(WithTransport(transport), WithCache(cache), WithClock(&clock))
+			get := func(date string) string {
+				var out struct {
+					CalendarDate string `json:"calendarDate"`
+				}
+				err := client.apiGet(&out, "/sleep-service/sleep/dailySleepData", url.Values{"date": {date}})
+				if err != nil {
+					t.Fatal(err)
+				}
+				return out.CalendarDate
+			}
+
+			// Old days are cached forever.
+			for range 2 {
+				if d := get("2024-08-10"); d != "2024-08-10" {
+					t.Errorf("got %q", d)
+				}
+			}
+			clock = fakeClock(now.AddDate(1, 0, 0))
+			get("2024-08-10")
+			if len(requests) != 1 {
+				t.Fatalf("old day: expected 1 request, got %d", len(requests))
+			}
+
+			// Recent days are revalidated once they are stale.
+			requests, clock = nil, fakeClock(now)
+			get("2024-08-16")
+			clock = fakeClock(now.Add(5 * time.Minute))
+			get("2024-08-16")
+			if len(requests) != 1 {
+				t.Fatalf("fresh: expected 1 request, got %d", len(requests))
+			}
+			clock = fakeClock(now.Add(15 * time.Minute))
+			if d := get("2024-08-16"); d != "2024-08-16" {
+				t.Errorf("revalidated: got %q", d)
+			}
+			if len(requests) != 2 || requests[1].Header.Get("If-None-Match") != `"v1"` {
+				t.Fatalf("expected a revalidation request, got %d requests", len(requests))
+			}
+			get("2024-08-16")
+			if len(requests) != 2 {
+				t.Errorf("revalidation should refresh the expiry, got %d requests", len(requests))
+			}
+
+			// Endpoints without a rule are not cached.
+			if err := client.Get(context.Background(), "/userprofile-service/userprofile/settings", nil, nil); err != nil {
+				t.Fatal(err)
+			}
+			if err := client.Get(context.Background(), "/userprofile-service/userprofile/settings", nil, nil); err != nil {
+				t.Fatal(err)
+			}
+			if len(requests) != 4 {
+				t.Errorf("uncached: expected 4 requests, got %d", len(requests))
+			}
+		})
+	}
+}
+
+func TestCachePerAccount(t *testing.T) {
+	var requests int
+	transport := &fakeTransport{fn: func(req *http.Request) (*http.Response, error) {
+		requests++
+		res := jsonResponse(req, http.StatusOK, `{"auth": "`+req.Header.Get(authHeader)+`"}`)
+		res.Header.Set("ETag", `"v1"`)
+		res.Header.Set("Set-Cookie", "session=secret")
+		res.Header.Set("X-Request-Id", "1")
+		return res, nil
+	}}
+	client := NewClient(WithTransport(transport), WithCache(NewMemoryCache(0)))
+	login := func(name string) {
+		access := &AccessToken{
+			AccessToken:         name,
+			TokenType:           "Bearer",
+			Expires:             time.Now().Add(time.Hour).UnixMilli(),
+			RefreshTokenExpires: time.Now().Add(2 * time.Hour).UnixMilli(),
+		}
+		if err := client.LoginWithTokens(&OAuth1Token{Token: name, TokenSecret: name}, access); err != nil {
+			t.Fatal(err)
+		}
+	}
+	get := func() (string, http.Header) {
+		req, err := client.apiRequest(context.Background(), http.MethodGet, "/hrv-service/hrv/2020-01-01", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		res, err := client.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer res.Body.Close()
+		var out struct {
+			Auth string `json:"auth"`
+		}
+		if err = json.NewDecoder(res.Body).Decode(&out); err != nil {
+			t.Fatal(err)
+		}
+		return out.Auth, res.Header
+	}
+
+	login("a")
+	get()
+	auth, header := get()
+	if auth != "Bearer a" || requests != 1 {
+		t.Fatalf("account a: got %q after %d requests", auth, requests)
+	}
+	if header.Get("ETag") != `"v1"` || header.Get("Content-Type") != "application/json" {
+		t.Errorf("cached response lost headers: %v", header)
+	}
+	if len(header.Get("Set-Cookie")) > 0 || len(header.Get("X-Request-Id")) > 0 {
+		t.Errorf("cached response kept headers it should have dropped: %v", header)
+	}
+	if err := client.Logout(); err != nil {
+		t.Fatal(err)
+	}
+	login("b")
+	if auth, _ := get(); auth != "Bearer b" || requests != 2 {
+		t.Errorf("account b was served a's response: got %q after %d requests", auth, requests)
+	}
+}
+
+func TestDefaultCacheRulesMatchEndpoints(t *testing.T) {
+	for _, r := range DefaultCacheRules {
+		matched := false
+		for _, e := range endpoints {
+			if ok, _ := path.Match(r.Pattern, e.Template); ok {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			t.Errorf("%s matches no endpoint template", r.Pattern)
+		}
+	}
+}
+
+func TestCacheRulesSeeCallTemplate(t *testing.T) {
+	var requests int
+	transport := &fakeTransport{fn: func(req *http.Request) (*http.Response, error) {
+		requests++
+		return jsonResponse(req, http.StatusOK, `{}`), nil
+	}}
+	client := NewClient(
+		WithTransport(transport),
+		WithEndpoints(map[string]Endpoint{"thing.get": {"GET", "/thing-service/thing/{name}"}}),
+		WithCache(NewMemoryCache(0), CacheRule{Pattern: "/thing-service/thing/{name}", TTL: FixedTTL(time.Hour)}),
+	)
+	for range 2 {
+		if err := client.Call(context.Background(), "thing.get", []any{"widget"}, nil, nil, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Raw requests to the same path are named from the client's templates.
+	if err := client.Get(context.Background(), "/thing-service/thing/widget", nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	if requests != 1 {
+		t.Errorf("expected 1 request, got %d", requests)
+	}
+	if err := NewClient(WithTransport(transport)).Call(context.Background(), "thing.get", []any{"x"}, nil, nil, nil); !errors.Is(err, ErrUnknownEndpoint) {
+		t.Errorf("endpoints leaked to another client: %v", err)
+	}
+}
+
+func TestCacheEviction(t *testing.T) {
+	dir, err := NewDirCache(t.TempDir(), 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, cache := range map[string]Cache{"Memory": NewMemoryCache(10), "Dir": dir} {
+		t.Run(name, func(t *testing.T) {
+			// The pause keeps the file times of DirCache apart.
+			step := func() { time.Sleep(10 * time.Millisecond) }
+			cache.Set("a", []byte("aaaa"))
+			step()
+			cache.Set("b", []byte("bbbb"))
+			step()
+			if _, ok := cache.Get("a"); !ok {
+				t.Fatal("a is missing")
+			}
+			step()
+			// c doesn't fit, b was used least recently.
+			cache.Set("c", []byte("cccc"))
+			for key, want := range map[string]bool{"a": true, "b": false, "c": true} {
+				if _, ok := cache.Get(key); ok != want {
+					t.Errorf("%s: cached %v, want %v", key, ok, want)
+				}
+			}
+			cache.Set("big", []byte("more than ten bytes"))
+			if _, ok := cache.Get("big"); ok {
+				t.Error("a value larger than the cache was kept")
+			}
+			if _, ok := cache.Get("c"); !ok {
+				t.Error("a value larger than the cache evicted c")
+			}
+		})
+	}
+}
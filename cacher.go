@@ -3,10 +3,10 @@ package garmin
 import (
 	"encoding/json"
 	"errors"
-	"fmt"
 	"io"
 	"os"
 	"path/filepath"
+	"sync"
 
 	"github.com/dghubble/oauth1"
 )
@@ -33,11 +33,14 @@ type TokenCacher interface {
 }
 
 type InMemTokenCacher struct {
+	mu sync.RWMutex
 	at *AccessToken
 	ot *oauth1.Token
 }
 
 func (imtc *InMemTokenCacher) GetAccessToken() (*AccessToken, error) {
+	imtc.mu.RLock()
+	defer imtc.mu.RUnlock()
 	if imtc.at == nil {
 		return nil, ErrTokenCacheNotFound
 	}
@@ -45,6 +48,8 @@ func (imtc *InMemTokenCacher) GetAccessToken() (*AccessToken, error) {
 }
 
 func (imtc *InMemTokenCacher) GetOAuth1Token() (*OAuth1Token, error) {
+	imtc.mu.RLock()
+	defer imtc.mu.RUnlock()
 	if imtc.ot == nil {
 		return nil, ErrTokenCacheNotFound
 	}
@@ -52,21 +57,29 @@ func (imtc *InMemTokenCacher) GetOAuth1Token() (*OAuth1Token, error) {
 }
 
 func (imtc *InMemTokenCacher) SaveAccessToken(at *AccessToken) error {
+	imtc.mu.Lock()
+	defer imtc.mu.Unlock()
 	imtc.at = at
 	return nil
 }
 
 func (imtc *InMemTokenCacher) SaveOAuth1Token(token *OAuth1Token) error {
+	imtc.mu.Lock()
+	defer imtc.mu.Unlock()
 	imtc.ot = token
 	return nil
 }
 
 func (imtc *InMemTokenCacher) DelAccessToken() error {
+	imtc.mu.Lock()
+	defer imtc.mu.Unlock()
 	imtc.at = nil
 	return nil
 }
 
 func (imtc *InMemTokenCacher) DelOAuth1Token() error {
+	imtc.mu.Lock()
+	defer imtc.mu.Unlock()
 	imtc.ot = nil
 	return nil
 }
@@ -79,10 +92,17 @@ type FileTokenCacher struct {
 	Path   string
 	Prefix string
 	mem    InMemTokenCacher
+	// mu serializes file access, mem has its own lock.
+	mu sync.Mutex
 }
 
+const (
+	accessTokenFile = "access_token.json"
+	oauth1TokenFile = "oauth1_token.json"
+)
+
 func (ftc *FileTokenCacher) SaveAccessToken(at *AccessToken) error {
-	if err := ftc.save(ftc.Prefix + "access_token.json", at); err != nil {
+	if err := ftc.save(ftc.Prefix+accessTokenFile, at); err != nil {
 		return err
 	}
 	return ftc.mem.SaveAccessToken(at)
@@ -93,14 +113,14 @@ func (ftc *FileTokenCacher) GetAccessToken() (*AccessToken, error) {
 	if t, err := ftc.mem.GetAccessToken(); err == nil && t != nil {
 		return t, nil
 	}
-	if err := ftc.get(ftc.Prefix + "access_token.json", &at); err != nil {
+	if err := ftc.get(ftc.Prefix+accessTokenFile, &at); err != nil {
 		return &at, err
 	}
 	return &at, ftc.mem.SaveAccessToken(&at)
 }
 
 func (ftc *FileTokenCacher) SaveOAuth1Token(token *OAuth1Token) error {
-	if err := ftc.save(ftc.Prefix + "oauth1_token.json", token); err != nil {
+	if err := ftc.save(ftc.Prefix+oauth1TokenFile, token); err != nil {
 		return err
 	}
 	return ftc.mem.SaveOAuth1Token(token)
@@ -111,7 +131,7 @@ func (ftc *FileTokenCacher) GetOAuth1Token() (*OAuth1Token, error) {
 	if t, err := ftc.mem.GetOAuth1Token(); err == nil && t != nil {
 		return t, nil
 	}
-	if err := ftc.get(ftc.Prefix + "oauth1_token.json", &token); err != nil {
+	if err := ftc.get(ftc.Prefix+oauth1TokenFile, &token); err != nil {
 		return &token, err
 	}
 	return &token, ftc.mem.SaveOAuth1Token(&token)
@@ -121,17 +141,31 @@ func (ftc *FileTokenCacher) DelAccessToken() error {
 	if err := ftc.mem.DelAccessToken(); err != nil {
 		return err
 	}
-	return os.Remove(filepath.Join(ftc.Path, fmt.Sprintf("%soauth1_token.json", ftc.Prefix)))
+	return ftc.del(ftc.Prefix + accessTokenFile)
 }
 
 func (ftc *FileTokenCacher) DelOAuth1Token() error {
 	if err := ftc.mem.DelOAuth1Token(); err != nil {
 		return err
 	}
-	return os.Remove(filepath.Join(ftc.Path, fmt.Sprintf("%soauth1_token.json", ftc.Prefix)))
+	return ftc.del(ftc.Prefix + oauth1TokenFile)
+}
+
+// del removes a token file. Deleting a token that was never saved is not an
+// error.
+func (ftc *FileTokenCacher) del(name string) error {
+	ftc.mu.Lock()
+	defer ftc.mu.Unlock()
+	err := os.Remove(filepath.Join(ftc.Path, filepath.Clean(name)))
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
 }
 
 func (ftc *FileTokenCacher) save(name string, token any) error {
+	ftc.mu.Lock()
+	defer ftc.mu.Unlock()
 	_, err := os.Stat(ftc.Path)
 	if os.IsNotExist(err) {
 		if err = os.MkdirAll(ftc.Path, 0750); err != nil {
@@ -156,6 +190,8 @@ func (ftc *FileTokenCacher) save(name string, token any) error {
 }
 
 func (ftc *FileTokenCacher) get(name string, token any) error {
+	ftc.mu.Lock()
+	defer ftc.mu.Unlock()
 	f, err := os.OpenFile(
 		filepath.Join(ftc.Path, filepath.Clean(name)),
 		os.O_RDONLY,
@@ -0,0 +1,99 @@
+package garmin_test
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	garmin "github.com/jylitalo/go-garmin"
+	"github.com/jylitalo/go-garmin/cachertest"
+)
+
+func TestInMemTokenCacher(t *testing.T) {
+	cachertest.Run(t, func(t *testing.T) garmin.TokenCacher {
+		return new(garmin.InMemTokenCacher)
+	})
+}
+
+func TestFileTokenCacher(t *testing.T) {
+	cachertest.Run(t, func(t *testing.T) garmin.TokenCacher {
+		return garmin.NewFileTokenCacher(t.TempDir())
+	})
+}
+
+func TestFileTokenCacher_Prefix(t *testing.T) {
+	cachertest.Run(t, func(t *testing.T) garmin.TokenCacher {
+		c := garmin.NewFileTokenCacher(t.TempDir())
+		c.Prefix = "user1_"
+		return c
+	})
+}
+
+func TestEncryptedFileTokenCacher(t *testing.T) {
+	cachertest.Run(t, func(t *testing.T) garmin.TokenCacher {
+		return garmin.NewEncryptedFileTokenCacher(t.TempDir(), "correct horse battery staple")
+	})
+}
+
+func TestFileTokenCacher_Persistent(t *testing.T) {
+	cachertest.RunPersistent(t, func(dir string) garmin.TokenCacher {
+		return garmin.NewFileTokenCacher(dir)
+	})
+}
+
+func TestEncryptedFileTokenCacher_Persistent(t *testing.T) {
+	cachertest.RunPersistent(t, func(dir string) garmin.TokenCacher {
+		return garmin.NewEncryptedFileTokenCacher(dir, "correct horse battery staple")
+	})
+}
+
+func TestEncryptedFileTokenCacher_Passphrase(t *testing.T) {
+	dir := t.TempDir()
+	c := garmin.NewEncryptedFileTokenCacher(dir, "first")
+	if err := c.SaveOAuth1Token(cachertest.OAuth1Token("secret")); err != nil {
+		t.Fatal(err)
+	}
+	b, err := os.ReadFile(filepath.Join(dir, "oauth1_token.json.enc"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains(b, []byte("secret-token")) {
+		t.Error("token is stored in plaintext")
+	}
+	// A second process with the same passphrase can read the cache.
+	if _, err = garmin.NewEncryptedFileTokenCacher(dir, "first").GetOAuth1Token(); err != nil {
+		t.Errorf("same passphrase: %v", err)
+	}
+	if err = c.SaveAccessToken(cachertest.AccessToken("secret", time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+
+	wrong := garmin.NewEncryptedFileTokenCacher(dir, "second")
+	if token, err := wrong.GetOAuth1Token(); !errors.Is(err, garmin.ErrTokenCacheCrypt) || token != nil {
+		t.Errorf("wrong passphrase: want %v, got %+v, %v", garmin.ErrTokenCacheCrypt, token, err)
+	}
+	if token, err := wrong.GetAccessToken(); !errors.Is(err, garmin.ErrTokenCacheCrypt) || token != nil {
+		t.Errorf("wrong passphrase: want %v, got %+v, %v", garmin.ErrTokenCacheCrypt, token, err)
+	}
+	if garmin.TokenCacheOk(wrong) {
+		t.Error("TokenCacheOk should be false with a wrong passphrase")
+	}
+	// Failed reads leave the cache as it was.
+	token, err := garmin.NewEncryptedFileTokenCacher(dir, "first").GetOAuth1Token()
+	if err != nil || *token != *cachertest.OAuth1Token("secret") {
+		t.Errorf("right passphrase after a wrong one: got %+v, %v", token, err)
+	}
+
+	// A corrupted file fails the same way.
+	name := filepath.Join(dir, "oauth1_token.json.enc")
+	b[len(b)-1] ^= 0xFF
+	if err = os.WriteFile(name, b, 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = garmin.NewEncryptedFileTokenCacher(dir, "first").GetOAuth1Token(); !errors.Is(err, garmin.ErrTokenCacheCrypt) {
+		t.Errorf("corrupted file: want %v, got %v", garmin.ErrTokenCacheCrypt, err)
+	}
+}
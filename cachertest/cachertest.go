@@ -0,0 +1,261 @@
+// Package cachertest is a conformance suite for garmin.TokenCacher
+// implementations.
+//
+//	func TestMyCacher(t *testing.T) {
+//	    cachertest.Run(t, func(t *testing.T) garmin.TokenCacher {
+//	        return mycacher.New(t.TempDir())
+//	    })
+//	}
+package cachertest
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	garmin "github.com/jylitalo/go-garmin"
+)
+
+// Factory returns a new, empty cacher. It is called once per sub-test.
+type Factory func(t *testing.T) garmin.TokenCacher
+
+// Run runs the conformance suite against the cachers returned by factory.
+func Run(t *testing.T, factory Factory) {
+	t.Helper()
+	t.Run("NotFound", func(t *testing.T) { testNotFound(t, factory(t)) })
+	t.Run("AccessToken", func(t *testing.T) { testAccessToken(t, factory(t)) })
+	t.Run("OAuth1Token", func(t *testing.T) { testOAuth1Token(t, factory(t)) })
+	t.Run("Overwrite", func(t *testing.T) { testOverwrite(t, factory(t)) })
+	t.Run("DeleteIndependent", func(t *testing.T) { testDeleteIndependent(t, factory(t)) })
+	t.Run("DeleteMissing", func(t *testing.T) { testDeleteMissing(t, factory(t)) })
+	t.Run("Expired", func(t *testing.T) { testExpired(t, factory(t)) })
+	t.Run("Concurrent", func(t *testing.T) { testConcurrent(t, factory(t)) })
+}
+
+// Opener returns a new cacher for the storage in dir. Each call should behave
+// like a different process opening the same cache.
+type Opener func(dir string) garmin.TokenCacher
+
+// RunPersistent checks that tokens survive reopening the cache, for cachers
+// that store tokens outside the process.
+func RunPersistent(t *testing.T, open Opener) {
+	t.Helper()
+	t.Run("Reopen", func(t *testing.T) {
+		dir := t.TempDir()
+		wantAccess, wantOAuth1 := AccessToken("a", time.Hour), OAuth1Token("a")
+		first := open(dir)
+		if err := first.SaveAccessToken(wantAccess); err != nil {
+			t.Fatal(err)
+		}
+		if err := first.SaveOAuth1Token(wantOAuth1); err != nil {
+			t.Fatal(err)
+		}
+		second := open(dir)
+		access, err := second.GetAccessToken()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if *access != *wantAccess {
+			t.Errorf("GetAccessToken after reopen: got %+v, want %+v", access, wantAccess)
+		}
+		oauth1, err := second.GetOAuth1Token()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if *oauth1 != *wantOAuth1 {
+			t.Errorf("GetOAuth1Token after reopen: got %+v, want %+v", oauth1, wantOAuth1)
+		}
+	})
+}
+
+// AccessToken returns a token that expires `d` from now.
+func AccessToken(name string, d time.Duration) *garmin.AccessToken {
+	now := time.Now()
+	return &garmin.AccessToken{
+		Scope:                 "CONNECT_READ CONNECT_WRITE",
+		JTI:                   name + "-jti",
+		AccessToken:           name + "-access-token",
+		TokenType:             "Bearer",
+		RefreshToken:          name + "-refresh-token",
+		ExpiresIn:             int(d.Seconds()),
+		Expires:               now.Add(d).UnixMilli(),
+		RefreshTokenExpiresIn: int(2 * d.Seconds()),
+		RefreshTokenExpires:   now.Add(2 * d).UnixMilli(),
+	}
+}
+
+// OAuth1Token returns a token with values derived from name.
+func OAuth1Token(name string) *garmin.OAuth1Token {
+	return &garmin.OAuth1Token{Token: name + "-token", TokenSecret: name + "-secret"}
+}
+
+func testNotFound(t *testing.T, c garmin.TokenCacher) {
+	if _, err := c.GetAccessToken(); !errors.Is(err, garmin.ErrTokenCacheNotFound) {
+		t.Errorf("GetAccessToken on empty cache: want %v, got %v", garmin.ErrTokenCacheNotFound, err)
+	}
+	if _, err := c.GetOAuth1Token(); !errors.Is(err, garmin.ErrTokenCacheNotFound) {
+		t.Errorf("GetOAuth1Token on empty cache: want %v, got %v", garmin.ErrTokenCacheNotFound, err)
+	}
+	if garmin.TokenCacheOk(c) {
+		t.Error("TokenCacheOk should be false for an empty cache")
+	}
+}
+
+func testAccessToken(t *testing.T, c garmin.TokenCacher) {
+	want := AccessToken("a", time.Hour)
+	if err := c.SaveAccessToken(want); err != nil {
+		t.Fatal(err)
+	}
+	got, err := c.GetAccessToken()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *got != *want {
+		t.Errorf("GetAccessToken: got %+v, want %+v", got, want)
+	}
+	if err = c.DelAccessToken(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = c.GetAccessToken(); !errors.Is(err, garmin.ErrTokenCacheNotFound) {
+		t.Errorf("GetAccessToken after delete: want %v, got %v", garmin.ErrTokenCacheNotFound, err)
+	}
+}
+
+func testOAuth1Token(t *testing.T, c garmin.TokenCacher) {
+	want := OAuth1Token("a")
+	if err := c.SaveOAuth1Token(want); err != nil {
+		t.Fatal(err)
+	}
+	got, err := c.GetOAuth1Token()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *got != *want {
+		t.Errorf("GetOAuth1Token: got %+v, want %+v", got, want)
+	}
+	if err = c.DelOAuth1Token(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = c.GetOAuth1Token(); !errors.Is(err, garmin.ErrTokenCacheNotFound) {
+		t.Errorf("GetOAuth1Token after delete: want %v, got %v", garmin.ErrTokenCacheNotFound, err)
+	}
+}
+
+func testOverwrite(t *testing.T, c garmin.TokenCacher) {
+	for _, name := range []string{"first", "second"} {
+		if err := c.SaveAccessToken(AccessToken(name, time.Hour)); err != nil {
+			t.Fatal(err)
+		}
+		if err := c.SaveOAuth1Token(OAuth1Token(name)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	at, err := c.GetAccessToken()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if at.AccessToken != "second-access-token" {
+		t.Errorf("access token was not overwritten: got %q", at.AccessToken)
+	}
+	ot, err := c.GetOAuth1Token()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ot.Token != "second-token" {
+		t.Errorf("oauth1 token was not overwritten: got %q", ot.Token)
+	}
+}
+
+func testDeleteIndependent(t *testing.T, c garmin.TokenCacher) {
+	if err := c.SaveAccessToken(AccessToken("a", time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.SaveOAuth1Token(OAuth1Token("a")); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.DelAccessToken(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.GetOAuth1Token(); err != nil {
+		t.Errorf("DelAccessToken must not delete the oauth1 token: %v", err)
+	}
+	if err := c.SaveAccessToken(AccessToken("b", time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.DelOAuth1Token(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.GetAccessToken(); err != nil {
+		t.Errorf("DelOAuth1Token must not delete the access token: %v", err)
+	}
+}
+
+func testDeleteMissing(t *testing.T, c garmin.TokenCacher) {
+	if err := c.DelAccessToken(); err != nil {
+		t.Errorf("DelAccessToken on empty cache: %v", err)
+	}
+	if err := c.DelOAuth1Token(); err != nil {
+		t.Errorf("DelOAuth1Token on empty cache: %v", err)
+	}
+}
+
+// testExpired checks that an expired token is either returned as-is, so the
+// client can see that it has expired, or rejected with ErrTokenCacheExpired.
+func testExpired(t *testing.T, c garmin.TokenCacher) {
+	want := AccessToken("expired", -time.Hour)
+	if err := c.SaveAccessToken(want); err != nil {
+		t.Fatal(err)
+	}
+	got, err := c.GetAccessToken()
+	if errors.Is(err, garmin.ErrTokenCacheExpired) {
+		return
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !got.ExpiresAt().Equal(want.ExpiresAt()) {
+		t.Errorf("expiry changed: got %v, want %v", got.ExpiresAt(), want.ExpiresAt())
+	}
+	if !got.RefreshTokenExpiresAt().Equal(want.RefreshTokenExpiresAt()) {
+		t.Errorf("refresh expiry changed: got %v, want %v", got.RefreshTokenExpiresAt(), want.RefreshTokenExpiresAt())
+	}
+	if got.ExpiresAt().After(time.Now()) {
+		t.Error("expired token should still be expired")
+	}
+}
+
+func testConcurrent(t *testing.T, c garmin.TokenCacher) {
+	const workers = 8
+	var wg sync.WaitGroup
+	errs := make(chan error, workers*4)
+	for i := range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			name := string(rune('a' + i))
+			errs <- c.SaveAccessToken(AccessToken(name, time.Hour))
+			errs <- c.SaveOAuth1Token(OAuth1Token(name))
+			if _, err := c.GetAccessToken(); err != nil {
+				errs <- err
+			}
+			if _, err := c.GetOAuth1Token(); err != nil {
+				errs <- err
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Error(err)
+		}
+	}
+	at, err := c.GetAccessToken()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(at.AccessToken) == 0 {
+		t.Error("concurrent writes left an empty access token")
+	}
+}
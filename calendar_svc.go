@@ -61,46 +61,46 @@ type Calendar struct {
 	StartDayOfMonth      int            `json:"startDayOfMonth"`
 	NumOfDaysInMonth     int            `json:"numOfDaysInMonth"`
 	NumOfDaysInPrevMonth int            `json:"numOfDaysInPrevMonth"`
-	StartDate            string         `json:"startDate"`
-	EndDate              string         `json:"endDate"`
+	StartDate            CalendarDate   `json:"startDate"`
+	EndDate              CalendarDate   `json:"endDate"`
 	Month                int            `json:"month"`
 	Year                 int            `json:"year"`
 	CalendarItems        []CalendarItem `json:"calendarItems"`
 }
 
 type CalendarItem struct {
-	ID                    any    `json:"id"`
-	GroupID               any    `json:"groupId"`
-	TrainingPlanID        any    `json:"trainingPlanId"`
-	ItemType              string `json:"itemType"`
-	ActivityTypeID        int    `json:"activityTypeId"`
-	WellnessActivityUUID  any    `json:"wellnessActivityUuid"`
-	Title                 string `json:"title"`
-	Date                  string `json:"date"`
-	Duration              any    `json:"duration"`
-	Distance              any    `json:"distance"`
-	Calories              any    `json:"calories"`
-	FloorsClimbed         any    `json:"floorsClimbed"`
-	AvgRespirationRate    any    `json:"avgRespirationRate"`
-	UnitOfPoolLength      any    `json:"unitOfPoolLength"`
-	Weight                any    `json:"weight"`
-	Difference            any    `json:"difference"`
-	CourseID              any    `json:"courseId"`
-	CourseName            any    `json:"courseName"`
-	SportTypeKey          any    `json:"sportTypeKey"`
-	URL                   string `json:"url"`
-	IsStart               any    `json:"isStart"`
-	IsRace                bool   `json:"isRace"`
-	RecurrenceID          any    `json:"recurrenceId"`
-	IsParent              any    `json:"isParent"`
-	ParentID              any    `json:"parentId"`
-	UserBadgeID           any    `json:"userBadgeId"`
-	BadgeCategoryTypeID   any    `json:"badgeCategoryTypeId"`
-	BadgeCategoryTypeDesc any    `json:"badgeCategoryTypeDesc"`
-	BadgeAwardedDate      any    `json:"badgeAwardedDate"`
-	BadgeViewed           any    `json:"badgeViewed"`
-	HideBadge             any    `json:"hideBadge"`
-	StartTimestampLocal   any    `json:"startTimestampLocal"`
+	ID                    any          `json:"id"`
+	GroupID               any          `json:"groupId"`
+	TrainingPlanID        any          `json:"trainingPlanId"`
+	ItemType              string       `json:"itemType"`
+	ActivityTypeID        int          `json:"activityTypeId"`
+	WellnessActivityUUID  any          `json:"wellnessActivityUuid"`
+	Title                 string       `json:"title"`
+	Date                  CalendarDate `json:"date"`
+	Duration              any          `json:"duration"`
+	Distance              any          `json:"distance"`
+	Calories              any          `json:"calories"`
+	FloorsClimbed         any          `json:"floorsClimbed"`
+	AvgRespirationRate    any          `json:"avgRespirationRate"`
+	UnitOfPoolLength      any          `json:"unitOfPoolLength"`
+	Weight                any          `json:"weight"`
+	Difference            any          `json:"difference"`
+	CourseID              any          `json:"courseId"`
+	CourseName            any          `json:"courseName"`
+	SportTypeKey          any          `json:"sportTypeKey"`
+	URL                   string       `json:"url"`
+	IsStart               any          `json:"isStart"`
+	IsRace                bool         `json:"isRace"`
+	RecurrenceID          any          `json:"recurrenceId"`
+	IsParent              any          `json:"isParent"`
+	ParentID              any          `json:"parentId"`
+	UserBadgeID           any          `json:"userBadgeId"`
+	BadgeCategoryTypeID   any          `json:"badgeCategoryTypeId"`
+	BadgeCategoryTypeDesc any          `json:"badgeCategoryTypeDesc"`
+	BadgeAwardedDate      any          `json:"badgeAwardedDate"`
+	BadgeViewed           any          `json:"badgeViewed"`
+	HideBadge             any          `json:"hideBadge"`
+	StartTimestampLocal   any          `json:"startTimestampLocal"`
 	EventTimeLocal        struct {
 		StartTimeHhMm string `json:"startTimeHhMm"`
 		TimeZoneID    string `json:"timeZoneId"`
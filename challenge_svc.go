@@ -0,0 +1,226 @@
+package garmin
+
+import (
+	"cmp"
+	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// ChallengeService has the ad-hoc challenges users create between
+// connections, and the badge challenges run by Garmin.
+type ChallengeService service
+
+// ChallengeType is what an ad-hoc challenge compares.
+type ChallengeType int
+
+const (
+	ChallengeSteps     ChallengeType = 1
+	ChallengeDistance  ChallengeType = 2
+	ChallengeElevation ChallengeType = 3
+	ChallengeDuration  ChallengeType = 4
+)
+
+type ChallengePlayer struct {
+	UserProfileID        int64   `json:"userProfileId"`
+	FullName             string  `json:"fullName"`
+	DisplayName          string  `json:"displayName"`
+	ProfileImageURLSmall string  `json:"profileImageUrlSmall"`
+	Ranking              int     `json:"ranking"`
+	Score                float64 `json:"score"`
+	PlayerStatusID       int     `json:"playerStatusId"`
+	OwnerPlayer          bool    `json:"ownerPlayer"`
+}
+
+type AdHocChallenge struct {
+	UUID                  string            `json:"uuid"`
+	Name                  string            `json:"adHocChallengeName"`
+	Description           string            `json:"adHocChallengeDesc"`
+	Type                  ChallengeType     `json:"socialChallengeType"`
+	ActivityTypeID        *int              `json:"socialChallengeActivityTypeId"`
+	StatusID              int               `json:"socialChallengeStatusId"`
+	OwnerID               int64             `json:"ownerId"`
+	StartDate             GarminLocalTime   `json:"startDate"`
+	EndDate               GarminLocalTime   `json:"endDate"`
+	CreateDate            *GarminLocalTime  `json:"createDate"`
+	DurationTypeID        int               `json:"durationTypeId"`
+	UserRanking           *int              `json:"userRanking"`
+	PlayersCount          int               `json:"playersCount"`
+	Players               []ChallengePlayer `json:"players"`
+	UserTimeZone          string            `json:"userTimeZone"`
+	PlayerJoined          bool              `json:"playerJoined"`
+	AdHocChallengeInvites []ChallengePlayer `json:"adHocChallengeInvites"`
+}
+
+// Leaderboard returns the players ordered by their ranking. Players without
+// a ranking yet, like those who haven't synced, come last.
+func (ac *AdHocChallenge) Leaderboard() []ChallengePlayer {
+	players := slices.Clone(ac.Players)
+	slices.SortStableFunc(players, func(a, b ChallengePlayer) int {
+		if (a.Ranking == 0) != (b.Ranking == 0) {
+			return cmp.Compare(b.Ranking, a.Ranking)
+		}
+		return cmp.Compare(a.Ranking, b.Ranking)
+	})
+	return players
+}
+
+// AdHoc returns the user's current and past ad-hoc challenges, newest first.
+// start is 1 based.
+func (cs *ChallengeService) AdHoc(start, limit int) (res []AdHocChallenge, err error) {
+	// GET https://connect.garmin.com/adhocchallenge-service/adHocChallenge/historical?start=1&limit=20
+	params := url.Values{"start": {strconv.Itoa(start)}, "limit": {strconv.Itoa(limit)}}
+	return res, cs.c.apiGet(&res, "/adhocchallenge-service/adHocChallenge/historical", params)
+}
+
+// AdHocChallenge returns the challenge with its players.
+func (cs *ChallengeService) AdHocChallenge(uuid string) (*AdHocChallenge, error) {
+	// GET https://connect.garmin.com/adhocchallenge-service/adHocChallenge/<uuid>
+	var ac AdHocChallenge
+	return &ac, cs.c.apiGet(&ac, "/adhocchallenge-service/adHocChallenge/"+url.PathEscape(uuid), nil)
+}
+
+// Leaderboard returns the players of the challenge ordered by their ranking.
+func (cs *ChallengeService) Leaderboard(uuid string) ([]ChallengePlayer, error) {
+	ac, err := cs.AdHocChallenge(uuid)
+	if err != nil {
+		return nil, err
+	}
+	return ac.Leaderboard(), nil
+}
+
+// NewAdHocChallenge is a challenge to create. Start and End are local dates,
+// and Invitees are the user profile IDs of the connections to invite.
+type NewAdHocChallenge struct {
+	Name           string
+	Description    string
+	Type           ChallengeType
+	ActivityTypeID *int
+	Start, End     time.Time
+	// TimeZone is the IANA name of the zone the challenge runs in, like
+	// "Europe/Helsinki". The time zone of the user's settings is used when it
+	// is empty.
+	TimeZone string
+	Invitees []int64
+}
+
+// CreateAdHoc creates the challenge, invites the connections and returns the
+// new challenge.
+func (cs *ChallengeService) CreateAdHoc(nc *NewAdHocChallenge) (*AdHocChallenge, error) {
+	// POST https://connect.garmin.com/adhocchallenge-service/adHocChallenge
+	tz := nc.TimeZone
+	if len(tz) == 0 {
+		settings, err := (*UserProfileService)(cs).Settings()
+		if err != nil {
+			return nil, fmt.Errorf("challenge time zone: %w", err)
+		}
+		tz = settings.TimeZone
+	}
+	// Go's names for the local and unknown zones aren't IANA names.
+	if len(tz) == 0 || tz == "Local" {
+		return nil, fmt.Errorf("challenge needs an IANA time zone, got %q", tz)
+	}
+	type invite struct {
+		UserProfileID int64 `json:"userProfileId"`
+	}
+	payload := struct {
+		Name           string   `json:"adHocChallengeName"`
+		Description    string   `json:"adHocChallengeDesc"`
+		Type           int      `json:"socialChallengeType"`
+		ActivityTypeID *int     `json:"socialChallengeActivityTypeId"`
+		StartDate      string   `json:"startDate"`
+		EndDate        string   `json:"endDate"`
+		UserTimeZone   string   `json:"userTimeZone"`
+		Invites        []invite `json:"adHocChallengeInvites"`
+	}{
+		Name:           nc.Name,
+		Description:    nc.Description,
+		Type:           int(nc.Type),
+		ActivityTypeID: nc.ActivityTypeID,
+		StartDate:      nc.Start.Format(time.DateOnly) + "T00:00:00.0",
+		EndDate:        nc.End.Format(time.DateOnly) + "T23:59:59.0",
+		UserTimeZone:   tz,
+		Invites:        []invite{},
+	}
+	for _, id := range nc.Invitees {
+		payload.Invites = append(payload.Invites, invite{id})
+	}
+	var ac AdHocChallenge
+	if err := cs.c.apiSend(&ac, "POST", "/adhocchallenge-service/adHocChallenge", &payload); err != nil {
+		return nil, err
+	}
+	return &ac, nil
+}
+
+// Join accepts the invitation to the challenge.
+func (cs *ChallengeService) Join(uuid string) error {
+	// PUT https://connect.garmin.com/adhocchallenge-service/adHocChallenge/<uuid>/player
+	return cs.player("PUT", uuid)
+}
+
+// Leave quits the challenge, or declines the invitation to it.
+func (cs *ChallengeService) Leave(uuid string) error {
+	// DELETE https://connect.garmin.com/adhocchallenge-service/adHocChallenge/<uuid>/player
+	return cs.player("DELETE", uuid)
+}
+
+func (cs *ChallengeService) player(method, uuid string) error {
+	p := fmt.Sprintf("/adhocchallenge-service/adHocChallenge/%s/player", url.PathEscape(uuid))
+	return cs.c.apiSend(nil, method, p, nil)
+}
+
+// BadgeChallenge is a challenge that earns a badge, like the monthly step
+// challenges.
+type BadgeChallenge struct {
+	UUID          string           `json:"uuid"`
+	Name          string           `json:"badgeChallengeName"`
+	CategoryID    int              `json:"challengeCategoryId"`
+	StatusID      int              `json:"badgeChallengeStatusId"`
+	StartDate     GarminLocalTime  `json:"startDate"`
+	EndDate       GarminLocalTime  `json:"endDate"`
+	BadgeID       int              `json:"badgeId"`
+	BadgeKey      string           `json:"badgeKey"`
+	BadgePoints   int              `json:"badgePoints"`
+	BadgeUnitID   *int             `json:"badgeUnitId"`
+	ProgressValue *float64         `json:"badgeProgressValue"`
+	TargetValue   *float64         `json:"badgeTargetValue"`
+	EarnedDate    *GarminLocalTime `json:"badgeEarnedDate"`
+	UserJoined    bool             `json:"userJoined"`
+	BadgeTypeIds  []int            `json:"badgeTypeIds"`
+}
+
+// Progress returns the progress towards the target in percent, capped at
+// 100. It is zero for challenges without a target.
+func (bc *BadgeChallenge) Progress() float64 {
+	if bc.ProgressValue == nil || bc.TargetValue == nil || *bc.TargetValue <= 0 {
+		return 0
+	}
+	return min(100, *bc.ProgressValue / *bc.TargetValue * 100)
+}
+
+type BadgeChallengeStatus string
+
+const (
+	BadgeChallengesAvailable BadgeChallengeStatus = "available"
+	// BadgeChallengesInProgress are the joined challenges not yet completed.
+	BadgeChallengesInProgress BadgeChallengeStatus = "non-completed"
+	BadgeChallengesCompleted  BadgeChallengeStatus = "completed"
+)
+
+// BadgeChallenges returns the badge challenges with the status. start is 1
+// based.
+func (cs *ChallengeService) BadgeChallenges(status BadgeChallengeStatus, start, limit int) (res []BadgeChallenge, err error) {
+	// GET https://connect.garmin.com/badgechallenge-service/badgeChallenge/non-completed?start=1&limit=20
+	params := url.Values{"start": {strconv.Itoa(start)}, "limit": {strconv.Itoa(limit)}}
+	return res, cs.c.apiGet(&res, "/badgechallenge-service/badgeChallenge/"+string(status), params)
+}
+
+// VirtualChallenges returns the virtual challenges, like climbing a famous
+// mountain, that are in progress.
+func (cs *ChallengeService) VirtualChallenges(start, limit int) (res []BadgeChallenge, err error) {
+	// GET https://connect.garmin.com/badgechallenge-service/virtualChallenge/inProgress?start=1&limit=20
+	params := url.Values{"start": {strconv.Itoa(start)}, "limit": {strconv.Itoa(limit)}}
+	return res, cs.c.apiGet(&res, "/badgechallenge-service/virtualChallenge/inProgress", params)
+}
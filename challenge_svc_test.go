@@ -0,0 +1,133 @@
+package garmin
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestChallenges(t *testing.T) {
+	var created map[string]any
+	transport := &fakeTransport{fn: func(req *http.Request) (*http.Response, error) {
+		switch req.Method + " " + req.URL.Path {
+		case "GET /adhocchallenge-service/adHocChallenge/abc":
+			return jsonResponse(req, http.StatusOK, `{
+				"uuid": "abc", "adHocChallengeName": "August steps", "socialChallengeType": 1,
+				"startDate": "2024-08-01T00:00:00.0", "endDate": "2024-08-31T23:59:59.0", "playersCount": 3,
+				"players": [
+					{"userProfileId": 4, "displayName": "d", "ranking": 0, "score": 0},
+					{"userProfileId": 2, "displayName": "b", "ranking": 2, "score": 250000},
+					{"userProfileId": 3, "displayName": "c", "ranking": 3, "score": 190000},
+					{"userProfileId": 1, "displayName": "a", "ranking": 1, "score": 310000}
+				]
+			}`), nil
+		case "POST /adhocchallenge-service/adHocChallenge":
+			b, _ := io.ReadAll(req.Body)
+			if err := json.Unmarshal(b, &created); err != nil {
+				return nil, err
+			}
+			return jsonResponse(req, http.StatusOK, `{"uuid": "def", "adHocChallengeName": "September steps"}`), nil
+		case "PUT /adhocchallenge-service/adHocChallenge/abc/player", "DELETE /adhocchallenge-service/adHocChallenge/abc/player":
+			return jsonResponse(req, http.StatusNoContent, ``), nil
+		case "GET /userprofile-service/userprofile/settings":
+			return jsonResponse(req, http.StatusOK, `{"displayName": "a", "timeZone": "America/Los_Angeles"}`), nil
+		case "GET /badgechallenge-service/badgeChallenge/non-completed":
+			return jsonResponse(req, http.StatusOK, `[
+				{"uuid": "b1", "badgeChallengeName": "August Weekend Warrior", "badgeProgressValue": 150000, "badgeTargetValue": 200000, "userJoined": true},
+				{"uuid": "b2", "badgeChallengeName": "Done already", "badgeProgressValue": 120, "badgeTargetValue": 100},
+				{"uuid": "b3", "badgeChallengeName": "No target", "badgeProgressValue": 5, "badgeTargetValue": null}
+			]`), nil
+		}
+		return jsonResponse(req, http.StatusNotFound, `{}`), nil
+	}}
+	api := NewAPI(NewClient(WithTransport(transport)))
+
+	board, err := api.Challenge.Leaderboard("abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(board) != 4 || board[0].DisplayName != "a" || board[2].Score != 190000 || board[3].DisplayName != "d" {
+		t.Errorf("leaderboard: %+v", board)
+	}
+
+	ac, err := api.Challenge.CreateAdHoc(&NewAdHocChallenge{
+		Name:     "September steps",
+		Type:     ChallengeSteps,
+		Start:    time.Date(2024, 9, 1, 0, 0, 0, 0, time.UTC),
+		End:      time.Date(2024, 9, 30, 0, 0, 0, 0, time.UTC),
+		Invitees: []int64{2, 3},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ac.UUID != "def" || created["endDate"] != "2024-09-30T23:59:59.0" || len(created["adHocChallengeInvites"].([]any)) != 2 ||
+		created["userTimeZone"] != "America/Los_Angeles" {
+		t.Errorf("created %+v from %v", ac, created)
+	}
+	if err = api.Challenge.Join("abc"); err != nil {
+		t.Error(err)
+	}
+	if err = api.Challenge.Leave("abc"); err != nil {
+		t.Error(err)
+	}
+
+	badges, err := api.Challenge.BadgeChallenges(BadgeChallengesInProgress, 1, 20)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, exp := range []float64{75, 100, 0} {
+		if p := badges[i].Progress(); p != exp {
+			t.Errorf("%s: got %v%%, want %v%%", badges[i].Name, p, exp)
+		}
+	}
+}
+
+func TestChallengeRequests(t *testing.T) {
+	testRequests(t, []requestTest{
+		{
+			name: "CreateAdHoc",
+			call: func(api *API) error {
+				_, err := api.Challenge.CreateAdHoc(&NewAdHocChallenge{
+					Name:     "September steps",
+					Type:     ChallengeSteps,
+					Start:    time.Date(2024, 9, 1, 0, 0, 0, 0, time.UTC),
+					End:      time.Date(2024, 9, 30, 0, 0, 0, 0, time.UTC),
+					TimeZone: "Europe/Helsinki",
+					Invitees: []int64{2, 3},
+				})
+				return err
+			},
+			response: `{"uuid": "def"}`,
+			method:   "POST",
+			path:     "/adhocchallenge-service/adHocChallenge",
+			body: `{"adHocChallengeName": "September steps", "adHocChallengeDesc": "", "socialChallengeType": 1,
+				"socialChallengeActivityTypeId": null, "startDate": "2024-09-01T00:00:00.0", "endDate": "2024-09-30T23:59:59.0",
+				"userTimeZone": "Europe/Helsinki", "adHocChallengeInvites": [{"userProfileId": 2}, {"userProfileId": 3}]}`,
+		},
+		{
+			name:   "Join",
+			call:   func(api *API) error { return api.Challenge.Join("abc") },
+			method: "PUT",
+			path:   "/adhocchallenge-service/adHocChallenge/abc/player",
+		},
+		{
+			name:   "Leave",
+			call:   func(api *API) error { return api.Challenge.Leave("abc") },
+			method: "DELETE",
+			path:   "/adhocchallenge-service/adHocChallenge/abc/player",
+		},
+	})
+
+	transport := &fakeTransport{fn: func(req *http.Request) (*http.Response, error) {
+		return jsonResponse(req, http.StatusOK, `{"timeZone": ""}`), nil
+	}}
+	api := NewAPI(NewClient(WithTransport(transport)))
+	if _, err := api.Challenge.CreateAdHoc(&NewAdHocChallenge{Name: "x", TimeZone: "Local"}); err == nil {
+		t.Error("created a challenge in the Local time zone")
+	}
+	if _, err := api.Challenge.CreateAdHoc(&NewAdHocChallenge{Name: "x"}); err == nil {
+		t.Error("created a challenge without a time zone")
+	}
+}
@@ -2,13 +2,19 @@ package garmin
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"log/slog"
+	"mime/multipart"
 	"net/http"
 	"net/http/cookiejar"
 	"net/url"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/jylitalo/go-garmin/internal/rt"
 )
@@ -20,27 +26,72 @@ var (
 
 const UserAgent = "com.garmin.android.apps.connectmobile"
 
+// DefaultRefreshMargin is the default Client.RefreshMargin.
+const DefaultRefreshMargin = time.Minute
+
 type Client struct {
 	Domain     string
 	Cacher     TokenCacher
 	AddReferer bool
 	MFAHandler func() (string, error)
-	Clock      Clock
-
-	http http.Client
-	prev *http.Response
+	// MFAProvider is used for MFA codes when set, otherwise MFAHandler.
+	MFAProvider MFAProvider
+	Clock       Clock
+	// RefreshMargin is how long before it expires the access token is
+	// refreshed, so that it doesn't expire while a request is in flight.
+	RefreshMargin time.Duration
+
+	log        *slog.Logger
+	observer   Observer
+	http       http.Client
+	prev       *http.Response
+	cookieOpts *cookiejar.Options
+	auth       *accessTokenInjector
+	cache      *rt.Caching
+	endpoints  map[string]Endpoint
+
+	// profile caches the display name of the logged in user.
+	profile struct {
+		sync.Mutex
+		displayName string
+	}
 }
 
 func NewClient(opts ...ClientOpt) *Client {
 	options := clientOpts{
-		Domain:    BaseDomain,
-		UserAgent: UserAgent,
-		Clock:     &DefaultClock,
-		Transport: http.DefaultTransport,
+		Domain:        BaseDomain,
+		UserAgent:     UserAgent,
+		Clock:         &DefaultClock,
+		RefreshMargin: DefaultRefreshMargin,
+		Transport:     http.DefaultTransport,
 	}
 	for _, o := range opts {
 		o(&options)
 	}
+	if options.Endpoints == nil {
+		options.Endpoints = endpoints
+	}
+	templates := templates(options.Endpoints)
+	for _, d := range options.debuggers {
+		if d.Logger == nil {
+			d.Logger = options.Logger
+		}
+	}
+	if options.Observer != nil {
+		options.Transport = (&rt.Metrics{Observer: options.Observer, Templates: templates, Now: options.Clock.Now}).Wrap(options.Transport)
+	}
+	if options.RateLimit != nil {
+		options.RateLimit.Now = options.Clock.Now
+		if tc, ok := options.Clock.(TimerClock); ok {
+			options.RateLimit.After = tc.After
+		}
+		options.Transport = options.RateLimit.Wrap(options.Transport)
+	}
+	if options.Cache != nil {
+		options.Cache.Now = options.Clock.Now
+		options.Cache.Templates = templates
+		options.Transport = options.Cache.Wrap(options.Transport)
+	}
 	uat := rt.NewUserAgent(options.UserAgent)
 	cookies, _ := cookiejar.New(options.CookieOpts)
 	c := http.Client{
@@ -48,23 +99,38 @@ func NewClient(opts ...ClientOpt) *Client {
 		Jar:       cookies,
 	}
 	client := Client{
-		Domain:     options.Domain,
-		Cacher:     options.Cacher,
-		MFAHandler: options.MFAHandler,
-		Clock:      options.Clock,
-		http:       c,
+		Domain:        options.Domain,
+		Cacher:        options.Cacher,
+		MFAHandler:    options.MFAHandler,
+		MFAProvider:   options.MFAProvider,
+		Clock:         options.Clock,
+		RefreshMargin: options.RefreshMargin,
+		log:           options.Logger,
+		observer:      options.Observer,
+		http:          c,
+		cookieOpts:    options.CookieOpts,
+		cache:         options.Cache,
+		endpoints:     options.Endpoints,
 	}
 	return &client
 }
 
 type clientOpts struct {
-	Transport  http.RoundTripper
-	CookieOpts *cookiejar.Options
-	UserAgent  string
-	Domain     string
-	Cacher     TokenCacher
-	MFAHandler func() (string, error)
-	Clock      Clock
+	Transport     http.RoundTripper
+	CookieOpts    *cookiejar.Options
+	UserAgent     string
+	Domain        string
+	Cacher        TokenCacher
+	MFAHandler    func() (string, error)
+	MFAProvider   MFAProvider
+	Clock         Clock
+	RefreshMargin time.Duration
+	Logger        *slog.Logger
+	Observer      Observer
+	Cache         *rt.Caching
+	RateLimit     *rt.RateLimiter
+	Endpoints     map[string]Endpoint
+	debuggers     []*rt.Debugger
 }
 
 type ClientOpt func(*clientOpts)
@@ -83,6 +149,10 @@ func WithMFAHandler(fn func() (string, error)) ClientOpt {
 	return func(c *clientOpts) { c.MFAHandler = fn }
 }
 
+func WithMFAProvider(p MFAProvider) ClientOpt {
+	return func(c *clientOpts) { c.MFAProvider = p }
+}
+
 func WithUserAgent(ua string) ClientOpt {
 	return func(c *clientOpts) {
 		c.UserAgent = ua
@@ -104,11 +174,35 @@ func WithCookieOpts(opts *cookiejar.Options) ClientOpt {
 
 func WithClock(clock Clock) ClientOpt { return func(co *clientOpts) { co.Clock = clock } }
 
+// WithRateLimit lets burst requests through at once and then one every
+// interval. Cached responses are not limited. The limiter uses the client's
+// Clock, and waits on it too when it is a TimerClock.
+func WithRateLimit(interval time.Duration, burst int) ClientOpt {
+	return func(co *clientOpts) { co.RateLimit = rt.NewRateLimiter(interval, burst) }
+}
+
+// WithRefreshMargin sets Client.RefreshMargin, DefaultRefreshMargin is used
+// otherwise.
+func WithRefreshMargin(d time.Duration) ClientOpt {
+	return func(co *clientOpts) { co.RefreshMargin = d }
+}
+
 func WithDebugging(enabled, skipBody bool) ClientOpt {
 	if !enabled {
 		return func(co *clientOpts) {}
 	}
-	return WithTransport(&rt.Debugger{SkipBody: skipBody})
+	return func(co *clientOpts) {
+		d := &rt.Debugger{SkipBody: skipBody}
+		WithTransport(d)(co)
+		co.debuggers = append(co.debuggers, d)
+	}
+}
+
+// WithLogger sets the logger used for debugging output and for logging in
+// and refreshing tokens, instead of slog.Default. Passwords and tokens are
+// never logged.
+func WithLogger(logger *slog.Logger) ClientOpt {
+	return func(co *clientOpts) { co.Logger = logger }
 }
 
 // Login will get an access token and auto authenticate every request sent by
@@ -118,15 +212,143 @@ func (c *Client) Login(email, password string) error {
 	if err != nil {
 		return err
 	}
+	c.authenticate(basic, access)
+	return nil
+}
+
+// Resume authenticates the client with the tokens held by Cacher, without a
+// password. An expired access token is exchanged for a new one using the
+// OAuth1 token. ErrReauthRequired is returned when there is no OAuth1 token in
+// the cache or Garmin no longer accepts it, other errors of Cacher are
+// returned as is.
+func (c *Client) Resume() error {
+	if c.Cacher == nil {
+		return fmt.Errorf("%w: client has no token cacher", ErrReauthRequired)
+	}
+	basic, err := c.Cacher.GetOAuth1Token()
+	if errors.Is(err, ErrTokenCacheNotFound) {
+		return fmt.Errorf("%w: %w", ErrReauthRequired, err)
+	}
+	if err != nil {
+		return err
+	}
+	access, err := c.Cacher.GetAccessToken()
+	if err != nil && !errors.Is(err, ErrTokenCacheNotFound) {
+		return err
+	}
+	return c.LoginWithTokens(basic, access)
+}
+
+// LoginWithTokens authenticates the client with tokens from an earlier login.
+// The access token may be nil or expired, in which case a new one is
+// exchanged for right away so that a revoked OAuth1 token is reported here as
+// ErrReauthRequired rather than on the first request. Tokens are saved to
+// Cacher when one is set.
+func (c *Client) LoginWithTokens(basic *OAuth1Token, access *AccessToken) error {
+	if basic == nil || len(basic.Token) == 0 {
+		return fmt.Errorf("%w: no oauth1 token", ErrReauthRequired)
+	}
+	if access == nil || len(access.AccessToken) == 0 || c.needsRefresh(access) {
+		refresher := oauth1TokenRefresher{token: basic, client: c}
+		at, err := refresher.Refresh(access)
+		if err != nil {
+			return err
+		}
+		access = at
+	} else if c.Cacher != nil {
+		if err := c.Cacher.SaveOAuth1Token(basic); err != nil {
+			return err
+		}
+		if err := c.Cacher.SaveAccessToken(access); err != nil {
+			return err
+		}
+	}
+	c.authenticate(basic, access)
+	return nil
+}
+
+func (c *Client) authenticate(basic *OAuth1Token, access *AccessToken) {
+	c.forgetProfile()
+	c.setCacheScope(basic)
 	refresher := oauth1TokenRefresher{
 		token:  basic,
 		client: c,
 	}
-	injector := accessTokenInjector{
+	if c.auth != nil {
+		// Logging in again replaces the tokens instead of stacking another
+		// injector on top of the old one.
+		c.auth.set(access, &refresher)
+		return
+	}
+	c.auth = &accessTokenInjector{
 		AccessToken: access,
 		refresher:   &refresher,
+		client:      c,
+	}
+	c.prependTransport(c.auth)
+}
+
+type logoutOpts struct {
+	sso bool
+}
+
+type LogoutOpt func(*logoutOpts)
+
+// WithSSOLogout makes Logout also end the session on Garmin's SSO server, the
+// same way signing out of the web app does.
+func WithSSOLogout() LogoutOpt { return func(lo *logoutOpts) { lo.sso = true } }
+
+// Logout undoes Login: requests are no longer authenticated, the cookie jar is
+// emptied and both tokens are deleted from Cacher. The client can be used to
+// log in again afterwards, with the same or a different account.
+func (c *Client) Logout(opts ...LogoutOpt) error {
+	var (
+		options logoutOpts
+		errs    []error
+	)
+	for _, o := range opts {
+		o(&options)
+	}
+	if options.sso {
+		errs = append(errs, c.ssoLogout())
+	}
+	if c.auth != nil {
+		c.http.Transport = rt.Remove(c.http.Transport, c.auth)
+		c.auth = nil
+	}
+	if jar, err := cookiejar.New(c.cookieOpts); err != nil {
+		errs = append(errs, err)
+	} else {
+		c.http.Jar = jar
+	}
+	c.prev = nil
+	c.forgetProfile()
+	c.setCacheScope(nil)
+	if c.Cacher != nil {
+		errs = append(errs, c.Cacher.DelAccessToken(), c.Cacher.DelOAuth1Token())
+	}
+	return errors.Join(errs...)
+}
+
+func (c *Client) ssoLogout() error {
+	// GET https://sso.garmin.com/sso/logout?service=https://connect.garmin.com/modern/
+	res, err := c.Do(&http.Request{
+		Method: "GET",
+		URL: c.url("sso", "/sso/logout", url.Values{
+			"service": []string{fmt.Sprintf("https://connect.%s/modern/", c.Domain)},
+		}),
+		Header: make(http.Header),
+	})
+	if err != nil {
+		return err
+	}
+	_, _ = io.Copy(io.Discard, res.Body)
+	if err = res.Body.Close(); err != nil {
+		return err
+	}
+	if res.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("sso logout: received bad status code: %d", res.StatusCode)
 	}
-	c.prependTransport(&injector)
 	return nil
 }
 
@@ -159,78 +381,157 @@ func (c *Client) url(sub, path string, params url.Values) *url.URL {
 	return &u
 }
 
-func (c *Client) apiGet(out any, path string, params url.Values) error {
-	host := fmt.Sprintf("connectapi.%s", c.Domain)
+// APIError is returned for responses with an unexpected status code.
+type APIError struct {
+	StatusCode int
+	Body       []byte
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("received bad status code: %d, body: %s", e.StatusCode, string(e.Body))
+}
+
+func newAPIError(res *http.Response) error {
+	buf := new(bytes.Buffer)
+	_, _ = buf.ReadFrom(res.Body)
+	return &APIError{StatusCode: res.StatusCode, Body: buf.Bytes()}
+}
+
+// apiRequest builds a request for the connectapi host. The path is sent as
+// is, so segments escaped with url.PathEscape arrive escaped once. It may have
+// a query, params are added to it.
+func (c *Client) apiRequest(ctx context.Context, method, path string, params url.Values) (*http.Request, error) {
+	u, err := url.Parse(path)
+	if err != nil {
+		return nil, err
+	}
+	u.Scheme = "https"
+	u.Host = fmt.Sprintf("connectapi.%s", c.Domain)
+	if len(params) > 0 {
+		q := u.Query()
+		for k, v := range params {
+			q[k] = append(q[k], v...)
+		}
+		u.RawQuery = q.Encode()
+	}
 	req := http.Request{
-		Method: "GET",
-		Host:   host,
-		URL: &url.URL{
-			Scheme: "https",
-			Host:   host,
-			Path:   path,
-		},
+		Method: method,
+		Host:   u.Host,
+		URL:    u,
 		Header: http.Header{
 			"Accept": []string{"application/json"},
 			"Nk":     []string{"NT"},
 		},
 	}
-	if len(params) > 0 {
-		req.URL.RawQuery = params.Encode()
+	return req.WithContext(ctx), nil
+}
+
+func (c *Client) apiGet(out any, path string, params url.Values) error {
+	return c.Get(context.Background(), path, params, out)
+}
+
+// Get sends an authenticated GET request to a connectapi endpoint, for
+// example one this package has no method for yet, and decodes the JSON
+// response into out. See Request for what out can be and which status codes
+// are errors.
+func (c *Client) Get(ctx context.Context, path string, params url.Values, out any) error {
+	req, err := c.apiRequest(ctx, http.MethodGet, path, params)
+	if err != nil {
+		return err
 	}
-	res, err := c.Do(&req)
+	return c.send(req, out)
+}
+
+// Request sends an authenticated request to a connectapi endpoint. The body
+// is sent as is when it is an io.Reader, form encoded when it is url.Values
+// and as JSON otherwise. The response is copied to out when it is an
+// io.Writer, ignored when out is nil and decoded from JSON otherwise. Status
+// codes other than 2xx are returned as *APIError.
+func (c *Client) Request(ctx context.Context, method, path string, body, out any) error {
+	req, err := c.apiRequest(ctx, method, path, nil)
+	if err != nil {
+		return err
+	}
+	switch b := body.(type) {
+	case nil:
+	case io.Reader:
+		req.Body = io.NopCloser(b)
+	case url.Values:
+		req.Header.Set("Content-Type", formContentType)
+		req.Body = io.NopCloser(strings.NewReader(b.Encode()))
+	default:
+		var buf bytes.Buffer
+		if err = json.NewEncoder(&buf).Encode(body); err != nil {
+			return err
+		}
+		req.Header.Set("Content-Type", "application/json")
+		req.Body = io.NopCloser(&buf)
+	}
+	return c.send(req, out)
+}
+
+// send sends the request and decodes the response into out, see Request.
+func (c *Client) send(req *http.Request, out any) error {
+	res, err := c.Do(req)
 	if err != nil {
 		return err
 	}
 	defer res.Body.Close()
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return newAPIError(res)
+	}
 	if res.StatusCode == http.StatusNoContent {
 		return nil
 	}
-	if res.StatusCode != http.StatusOK {
-		buf := new(bytes.Buffer)
-		_, _ = buf.ReadFrom(res.Body)
-		return fmt.Errorf("received bad status code: %d, body: %s", res.StatusCode, string(buf.Bytes()))
+	return decodeResponse(res.Body, out)
+}
+
+func decodeResponse(r io.Reader, out any) error {
+	switch o := out.(type) {
+	case nil:
+		return nil
+	case io.Writer:
+		_, err := io.Copy(o, r)
+		return err
+	}
+	err := json.NewDecoder(r).Decode(out)
+	if errors.Is(err, io.EOF) {
+		// An empty body
+		return nil
 	}
-	return json.NewDecoder(res.Body).Decode(out)
+	return err
 }
 
-func (c *Client) api(out any, method, path string, params url.Values, payload any) (int, error) {
-	host := fmt.Sprintf("connectapi.%s", c.Domain)
-	req := http.Request{
-		Method: method,
-		Host:   host,
-		URL: &url.URL{
-			Scheme: "https",
-			Host:   host,
-			Path:   path,
-		},
-		Header: http.Header{
-			"Accept": []string{"application/json"},
-		},
+// apiSend sends a request with a JSON payload, or none when payload is nil,
+// and decodes the response into out. Status codes other than 2xx are returned
+// as *APIError.
+func (c *Client) apiSend(out any, method, path string, payload any) error {
+	return c.Request(context.Background(), method, path, payload, out)
+}
+
+// upload sends a file as multipart form data, the same way the web app
+// uploads FIT files. Status codes other than 2xx are returned as *APIError.
+func (c *Client) upload(out any, path, filename string, file io.Reader) error {
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	part, err := mw.CreateFormFile("file", filename)
+	if err != nil {
+		return err
 	}
-	if len(params) > 0 {
-		req.URL.RawQuery = params.Encode()
+	if _, err = io.Copy(part, file); err != nil {
+		return err
 	}
-	var (
-		err  error
-		body bytes.Buffer
-	)
-	if payload != nil {
-		req.Header.Set("Content-Type", "application/json")
-		err = json.NewEncoder(&body).Encode(payload)
-		if err != nil {
-			return 0, err
-		}
-		req.Body = io.NopCloser(&body)
+	if err = mw.Close(); err != nil {
+		return err
 	}
-	res, err := c.Do(&req)
+	req, err := c.apiRequest(context.Background(), http.MethodPost, path, nil)
 	if err != nil {
-		return 0, err
-	}
-	defer res.Body.Close()
-	if out != nil {
-		err = json.NewDecoder(res.Body).Decode(out)
+		return err
 	}
-	return res.StatusCode, err
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+	req.Body = io.NopCloser(&body)
+	req.ContentLength = int64(body.Len())
+	return c.send(req, out)
 }
 
 func (c *Client) prependTransport(rt rt.RoundTripper) {
@@ -315,3 +616,23 @@ func (ub *URLBuilder) Clear() *URLBuilder {
 	ub.u.RawFragment = ""
 	return ub
 }
+
+func (c *Client) logger() *slog.Logger {
+	if c.log == nil {
+		return slog.Default()
+	}
+	return c.log
+}
+
+func (c *Client) now() time.Time {
+	if c.Clock == nil {
+		return time.Now()
+	}
+	return c.Clock.Now()
+}
+
+// needsRefresh reports whether the access token expires within the refresh
+// margin.
+func (c *Client) needsRefresh(at *AccessToken) bool {
+	return at.expired(c.now().Add(c.RefreshMargin))
+}
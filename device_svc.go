@@ -413,8 +413,7 @@ func (d *DeviceService) SendDeviceMessages(msgs []DeviceMessage) (res []Uploaded
 	// Content-Type: application/json
 	//
 	// [{ ... }]
-	_, err = d.c.api(&res, "POST", "/device-service/devicemessage/messages", nil, msgs)
-	return res, err
+	return res, d.c.apiSend(&res, "POST", "/device-service/devicemessage/messages", msgs)
 }
 
 func (d *DeviceService) SendCourceToDevice(deviceID, courseID int64, courseName string) error {
@@ -0,0 +1,310 @@
+package garmin
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"golang.org/x/crypto/scrypt"
+
+	"github.com/dghubble/oauth1"
+)
+
+// DefaultPassphraseEnv is the environment variable read by
+// NewEncryptedFileTokenCacherFromEnv when no other name is given.
+const DefaultPassphraseEnv = "GOGARMIN_TOKEN_PASSPHRASE"
+
+var (
+	ErrNoPassphrase    = errors.New("no passphrase given for encrypted token cache")
+	ErrTokenCacheCrypt = errors.New("could not decrypt token cache, wrong passphrase or corrupted file")
+)
+
+// encryptedMagic starts every encrypted token file so that the format can be
+// changed later.
+var encryptedMagic = []byte("GGTC1")
+
+const (
+	saltSize = 16
+	keySize  = 32
+	// scrypt parameters recommended for interactive logins in 2017, which are
+	// still a sensible default for a file read once per process.
+	scryptN = 1 << 15
+	scryptR = 8
+	scryptP = 1
+)
+
+// EncryptedFileTokenCacher stores tokens in files encrypted with AES-256-GCM.
+// The key is derived from a passphrase with scrypt and a random salt that is
+// stored next to the ciphertext.
+//
+// Writes go to a temporary file that is renamed into place, and every access
+// holds a file lock so that several processes can share one cache directory.
+type EncryptedFileTokenCacher struct {
+	Path   string
+	Prefix string
+
+	passphrase []byte
+	mu         sync.Mutex
+	// salt and key are the ones used for writing. keys caches keys derived
+	// for other salts found while reading.
+	salt []byte
+	key  []byte
+	keys map[string][]byte
+}
+
+func NewEncryptedFileTokenCacher(p, passphrase string) *EncryptedFileTokenCacher {
+	return &EncryptedFileTokenCacher{
+		Path:       p,
+		passphrase: []byte(passphrase),
+		keys:       make(map[string][]byte),
+	}
+}
+
+// NewEncryptedFileTokenCacherFromEnv reads the passphrase from the environment
+// variable env, or DefaultPassphraseEnv if env is empty.
+func NewEncryptedFileTokenCacherFromEnv(p, env string) (*EncryptedFileTokenCacher, error) {
+	if len(env) == 0 {
+		env = DefaultPassphraseEnv
+	}
+	passphrase := os.Getenv(env)
+	if len(passphrase) == 0 {
+		return nil, fmt.Errorf("%w: $%s is not set", ErrNoPassphrase, env)
+	}
+	return NewEncryptedFileTokenCacher(p, passphrase), nil
+}
+
+func (etc *EncryptedFileTokenCacher) SaveAccessToken(at *AccessToken) error {
+	return etc.save(etc.Prefix+accessTokenFile, at)
+}
+
+func (etc *EncryptedFileTokenCacher) GetAccessToken() (*AccessToken, error) {
+	var at AccessToken
+	if err := etc.get(etc.Prefix+accessTokenFile, &at); err != nil {
+		return nil, err
+	}
+	return &at, nil
+}
+
+func (etc *EncryptedFileTokenCacher) DelAccessToken() error {
+	return etc.del(etc.Prefix + accessTokenFile)
+}
+
+func (etc *EncryptedFileTokenCacher) SaveOAuth1Token(token *OAuth1Token) error {
+	return etc.save(etc.Prefix+oauth1TokenFile, token)
+}
+
+func (etc *EncryptedFileTokenCacher) GetOAuth1Token() (*OAuth1Token, error) {
+	var token oauth1.Token
+	if err := etc.get(etc.Prefix+oauth1TokenFile, &token); err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+func (etc *EncryptedFileTokenCacher) DelOAuth1Token() error {
+	return etc.del(etc.Prefix + oauth1TokenFile)
+}
+
+func (etc *EncryptedFileTokenCacher) filename(name string) string {
+	return filepath.Join(etc.Path, filepath.Clean(name)+".enc")
+}
+
+func (etc *EncryptedFileTokenCacher) lockname() string {
+	return filepath.Join(etc.Path, etc.Prefix+"tokens.lock")
+}
+
+func (etc *EncryptedFileTokenCacher) save(name string, token any) error {
+	if err := os.MkdirAll(etc.Path, 0700); err != nil {
+		return err
+	}
+	plain, err := json.Marshal(token)
+	if err != nil {
+		return err
+	}
+	sealed, err := etc.seal(plain)
+	if err != nil {
+		return err
+	}
+	unlock, err := lockFile(etc.lockname(), true)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	return writeFileAtomic(etc.filename(name), sealed, 0600)
+}
+
+func (etc *EncryptedFileTokenCacher) get(name string, token any) error {
+	unlock, err := lockFile(etc.lockname(), false)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return ErrTokenCacheNotFound
+		}
+		return err
+	}
+	b, err := os.ReadFile(etc.filename(name))
+	unlock()
+	if err != nil {
+		if os.IsNotExist(err) {
+			return ErrTokenCacheNotFound
+		}
+		return err
+	}
+	plain, err := etc.open(b)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(plain, token)
+}
+
+func (etc *EncryptedFileTokenCacher) del(name string) error {
+	unlock, err := lockFile(etc.lockname(), true)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	defer unlock()
+	err = os.Remove(etc.filename(name))
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// seal encrypts plain into magic | salt | nonce | ciphertext.
+func (etc *EncryptedFileTokenCacher) seal(plain []byte) ([]byte, error) {
+	salt, key, err := etc.writeKey()
+	if err != nil {
+		return nil, err
+	}
+	aead, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err = rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	out := make([]byte, 0, len(encryptedMagic)+saltSize+len(nonce)+len(plain)+aead.Overhead())
+	out = append(out, encryptedMagic...)
+	out = append(out, salt...)
+	out = append(out, nonce...)
+	// The header is authenticated so it can't be swapped between files.
+	return aead.Seal(out, nonce, plain, out), nil
+}
+
+func (etc *EncryptedFileTokenCacher) open(b []byte) ([]byte, error) {
+	if !bytes.HasPrefix(b, encryptedMagic) || len(b) < len(encryptedMagic)+saltSize {
+		return nil, ErrTokenCacheCrypt
+	}
+	salt := b[len(encryptedMagic) : len(encryptedMagic)+saltSize]
+	key, err := etc.readKey(salt)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	headerSize := len(encryptedMagic) + saltSize + aead.NonceSize()
+	if len(b) < headerSize+aead.Overhead() {
+		return nil, ErrTokenCacheCrypt
+	}
+	nonce := b[len(encryptedMagic)+saltSize : headerSize]
+	plain, err := aead.Open(nil, nonce, b[headerSize:], b[:headerSize])
+	if err != nil {
+		return nil, ErrTokenCacheCrypt
+	}
+	return plain, nil
+}
+
+func (etc *EncryptedFileTokenCacher) writeKey() (salt, key []byte, err error) {
+	etc.mu.Lock()
+	defer etc.mu.Unlock()
+	if etc.key != nil {
+		return etc.salt, etc.key, nil
+	}
+	if len(etc.passphrase) == 0 {
+		return nil, nil, ErrNoPassphrase
+	}
+	salt = make([]byte, saltSize)
+	if _, err = rand.Read(salt); err != nil {
+		return nil, nil, err
+	}
+	key, err = scrypt.Key(etc.passphrase, salt, scryptN, scryptR, scryptP, keySize)
+	if err != nil {
+		return nil, nil, err
+	}
+	etc.salt, etc.key = salt, key
+	etc.keys[string(salt)] = key
+	return salt, key, nil
+}
+
+func (etc *EncryptedFileTokenCacher) readKey(salt []byte) ([]byte, error) {
+	etc.mu.Lock()
+	defer etc.mu.Unlock()
+	if etc.keys == nil {
+		etc.keys = make(map[string][]byte)
+	}
+	if key, ok := etc.keys[string(salt)]; ok {
+		return key, nil
+	}
+	if len(etc.passphrase) == 0 {
+		return nil, ErrNoPassphrase
+	}
+	key, err := scrypt.Key(etc.passphrase, salt, scryptN, scryptR, scryptP, keySize)
+	if err != nil {
+		return nil, err
+	}
+	etc.keys[string(salt)] = key
+	return key, nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// writeFileAtomic writes to a temporary file in the same directory and renames
+// it over name, so readers see either the old or the new file.
+func writeFileAtomic(name string, data []byte, perm os.FileMode) error {
+	f, err := os.CreateTemp(filepath.Dir(name), "."+filepath.Base(name)+".tmp*")
+	if err != nil {
+		return err
+	}
+	tmp := f.Name()
+	defer func() {
+		if err != nil {
+			_ = os.Remove(tmp)
+		}
+	}()
+	if _, err = io.Copy(f, bytes.NewReader(data)); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err = f.Chmod(perm); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err = f.Sync(); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err = f.Close(); err != nil {
+		return err
+	}
+	err = os.Rename(tmp, name)
+	return err
+}
@@ -0,0 +1,249 @@
+package garmin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/jylitalo/go-garmin/internal/rt"
+)
+
+var ErrUnknownEndpoint = errors.New("unknown endpoint")
+
+// Endpoint is a connectapi endpoint. Template is its path with placeholders
+// in braces, like /activity-service/activity/{id}/details.
+type Endpoint struct {
+	Method   string
+	Template string
+}
+
+var placeholderRe = regexp.MustCompile(`\{[^}]+\}`)
+
+// Placeholders returns the names of the placeholders in Template in order.
+func (e Endpoint) Placeholders() []string {
+	var names []string
+	for _, m := range placeholderRe.FindAllString(e.Template, -1) {
+		names = append(names, m[1:len(m)-1])
+	}
+	return names
+}
+
+// Path fills in the placeholders of Template with args in order. A time.Time
+// is formatted as a date, everything else with fmt.Sprint.
+func (e Endpoint) Path(args ...any) (string, error) {
+	if n := len(e.Placeholders()); n != len(args) {
+		return "", fmt.Errorf("%s needs %d arguments, got %d", e.Template, n, len(args))
+	}
+	i := 0
+	return placeholderRe.ReplaceAllStringFunc(e.Template, func(string) string {
+		var s string
+		switch a := args[i].(type) {
+		case time.Time:
+			s = a.Format(time.DateOnly)
+		case CalendarDate:
+			s = a.String()
+		default:
+			s = fmt.Sprint(a)
+		}
+		i++
+		return url.PathEscape(s)
+	}), nil
+}
+
+// endpoints are the endpoints this package knows about by name. The names are
+// the service and method, like "activity.details".
+var endpoints = map[string]Endpoint{
+	"activity.get":                      {"GET", "/activity-service/activity/{id}"},
+	"activity.details":                  {"GET", "/activity-service/activity/{id}/details"},
+	"activity.typedSplits":              {"GET", "/activity-service/activity/{id}/typedsplits"},
+	"activity.splits":                   {"GET", "/activity-service/activity/{id}/splits"},
+	"activity.splitSummaries":           {"GET", "/activity-service/activity/{id}/split_summaries"},
+	"activity.hrTimeInZones":            {"GET", "/activity-service/activity/{id}/hrTimeInZones"},
+	"activity.powerTimeInZones":         {"GET", "/activity-service/activity/{id}/powerTimeInZones"},
+	"activity.weather":                  {"GET", "/activity-service/activity/{id}/weather"},
+	"activity.activityTypes":            {"GET", "/activity-service/activity/activityTypes"},
+	"activity.eventTypes":               {"GET", "/activity-service/activity/eventTypes"},
+	"activityList.search":               {"GET", "/activitylist-service/activities/search/activities"},
+	"activityList.firstLast":            {"GET", "/activitylist-service/activities/first-last"},
+	"badge.earned":                      {"GET", "/badge-service/badge/earned"},
+	"badge.detail":                      {"GET", "/badge-service/badge/detail/v2/{id}"},
+	"badge.available":                   {"GET", "/badge-service/badge/available"},
+	"badge.earnedForActivity":           {"GET", "/badge-service/badge/{userUUID}/earned/activity/{activityID}"},
+	"badge.attributes":                  {"GET", "/badge-service/badge/attributes"},
+	"badge.leaderboard":                 {"GET", "/badge-service/badge/leaderboard"},
+	"biometric.lactateThreshold":        {"GET", "/biometric-service/biometric/latestLactateThreshold"},
+	"biometric.ftp":                     {"GET", "/biometric-service/biometric/latestFunctionalThresholdPower/{sport}"},
+	"biometric.heartRateZones":          {"GET", "/biometric-service/heartRateZones"},
+	"biometric.updateHeartRateZones":    {"PUT", "/biometric-service/heartRateZones"},
+	"biometric.powerZones":              {"GET", "/biometric-service/powerZones/sports/all"},
+	"biometric.updatePowerZones":        {"PUT", "/biometric-service/powerZones"},
+	"bloodPressure.range":               {"GET", "/bloodpressure-service/bloodpressure/range/{start}/{end}"},
+	"bloodPressure.add":                 {"POST", "/bloodpressure-service/bloodpressure"},
+	"bloodPressure.delete":              {"DELETE", "/bloodpressure-service/bloodpressure/{date}/{version}"},
+	"calendar.preferences":              {"GET", "/calendar-service/preferences"},
+	"calendar.year":                     {"GET", "/calendar-service/year/{year}"},
+	"calendar.month":                    {"GET", "/calendar-service/year/{year}/month/{month}"},
+	"calendar.week":                     {"GET", "/calendar-service/year/{year}/month/{month}/day/{day}/start/{start}"},
+	"calendar.upcomingEvents":           {"GET", "/calendar-service/events/upcoming"},
+	"calendar.raceEventProviders":       {"GET", "/calendar-service/race-events/providers"},
+	"challenge.adHoc":                   {"GET", "/adhocchallenge-service/adHocChallenge/historical"},
+	"challenge.adHocChallenge":          {"GET", "/adhocchallenge-service/adHocChallenge/{uuid}"},
+	"challenge.createAdHoc":             {"POST", "/adhocchallenge-service/adHocChallenge"},
+	"challenge.join":                    {"PUT", "/adhocchallenge-service/adHocChallenge/{uuid}/player"},
+	"challenge.leave":                   {"DELETE", "/adhocchallenge-service/adHocChallenge/{uuid}/player"},
+	"challenge.badgeChallenges":         {"GET", "/badgechallenge-service/badgeChallenge/{status}"},
+	"challenge.virtualChallenges":       {"GET", "/badgechallenge-service/virtualChallenge/inProgress"},
+	"course.owner":                      {"GET", "/course-service/course/owner/{displayName}"},
+	"course.metadata":                   {"GET", "/course-service/course/metadata/{id}"},
+	"course.courses":                    {"GET", "/web-gateway/course/owner"},
+	"device.devices":                    {"GET", "/device-service/deviceregistration/devices"},
+	"device.allDevices":                 {"GET", "/device-service/deviceregistration/devices/all/{userUUID}"},
+	"device.lastUsed":                   {"GET", "/device-service/deviceservice/mylastused"},
+	"device.userDevice":                 {"GET", "/device-service/deviceservice/user-device/{deviceID}"},
+	"device.messages":                   {"GET", "/device-service/devicemessage/messages"},
+	"device.sendMessages":               {"POST", "/device-service/devicemessage/messages"},
+	"device.messageCount":               {"GET", "/device-service/devicemessage/message/count"},
+	"device.primaryTrainingDevice":      {"GET", "/web-gateway/device-info/primary-training-device"},
+	"fitnessAge.get":                    {"GET", "/fitnessage-service/fitnessage/{date}"},
+	"fitnessAge.daily":                  {"GET", "/fitnessage-service/stats/daily/{start}/{end}"},
+	"fitnessAge.weekly":                 {"GET", "/fitnessage-service/stats/weekly/{date}/{weeks}"},
+	"fitnessStats.availableMetrics":     {"GET", "/fitnessstats-service/activity/availableMetrics"},
+	"fitnessStats.activity":             {"GET", "/fitnessstats-service/activity"},
+	"gear.list":                         {"GET", "/gear-service/gear/filterGear"},
+	"gear.stats":                        {"GET", "/gear-service/gear/stats/{uuid}"},
+	"gear.activities":                   {"GET", "/activitylist-service/activities/{uuid}/gear"},
+	"gear.defaults":                     {"GET", "/gear-service/gear/user/{userProfilePk}/activityTypes"},
+	"gear.create":                       {"POST", "/gear-service/gear"},
+	"gear.update":                       {"PUT", "/gear-service/gear/{uuid}"},
+	"gear.delete":                       {"DELETE", "/gear-service/gear/{uuid}"},
+	"gear.setDefault":                   {"PUT", "/gear-service/gear/{uuid}/activityType/{activityType}/default/true"},
+	"gear.link":                         {"PUT", "/gear-service/gear/link/{uuid}/activity/{activityID}"},
+	"gear.unlink":                       {"PUT", "/gear-service/gear/unlink/{uuid}/activity/{activityID}"},
+	"goal.goals":                        {"GET", "/goal-service/goal/goals"},
+	"hrv.daily":                         {"GET", "/hrv-service/hrv/{date}"},
+	"hrv.summaries":                     {"GET", "/hrv-service/hrv/daily/{start}/{end}"},
+	"performance.racePredictions":       {"GET", "/metrics-service/metrics/racepredictions/latest/{displayName}"},
+	"performance.racePredictionHistory": {"GET", "/metrics-service/metrics/racepredictions/daily/{displayName}"},
+	"performance.enduranceScore":        {"GET", "/metrics-service/metrics/endurancescore"},
+	"performance.enduranceScoreHistory": {"GET", "/metrics-service/metrics/endurancescore/stats"},
+	"performance.hillScore":             {"GET", "/metrics-service/metrics/hillscore"},
+	"performance.hillScoreHistory":      {"GET", "/metrics-service/metrics/hillscore/stats"},
+	"personalRecord.prs":                {"GET", "/personalrecord-service/personalrecord/prs/{userUUID}"},
+	"personalRecord.candidates":         {"GET", "/personalrecord-service/personalrecordcandidate/{userUUID}"},
+	"personalRecord.types":              {"GET", "/personalrecord-service/personalrecordtype/prtypes/{userUUID}"},
+	"sleep.daily":                       {"GET", "/sleep-service/sleep/dailySleepData"},
+	"sleep.dailyStats":                  {"GET", "/sleep-service/stats/sleep/daily/{start}/{end}"},
+	"sleep.weeklyStats":                 {"GET", "/sleep-service/stats/sleep/weekly/{date}/{weeks}"},
+	"trainingReadiness.daily":           {"GET", "/metrics-service/metrics/trainingreadiness/{date}"},
+	"trainingStatus.aggregated":         {"GET", "/metrics-service/metrics/trainingstatus/aggregated/{date}"},
+	"trainingStatus.daily":              {"GET", "/metrics-service/metrics/trainingstatus/daily/{start}/{end}"},
+	"trainingStatus.loadBalance":        {"GET", "/metrics-service/metrics/trainingloadbalance/daily/{start}/{end}"},
+	"trainingStatus.maxMet":             {"GET", "/metrics-service/metrics/maxmet/daily/{start}/{end}"},
+	"userProfile.base":                  {"GET", "/userprofile-service/userprofile/userProfileBase"},
+	"userProfile.settings":              {"GET", "/userprofile-service/userprofile/user-settings"},
+	"userProfile.updateSettings":        {"PUT", "/userprofile-service/userprofile/user-settings"},
+	"userProfile.personalInfo":          {"GET", "/userprofile-service/userprofile/personal-information/{userUUID}"},
+	"userProfile.socialProfile":         {"GET", "/userprofile-service/socialProfile/{displayName}"},
+	"userProfile.publicProfile":         {"GET", "/userprofile-service/socialProfile/public/{displayName}"},
+	"userProfile.profileStatus":         {"GET", "/userprofile-service/connection/profileStatus/{displayName}"},
+	"userProfile.updateSocialProfile":   {"PUT", "/userprofile-service/socialProfile/{displayName}"},
+	"userProfile.displaySettings":       {"GET", "/userprofile-service/userprofile/settings"},
+	"userProfile.pulseOxCapable":        {"GET", "/userprofile-service/userprofile/capableEnable/pulseOxCapable"},
+	"userProfile.segmentLeaderboard":    {"GET", "/userprofile-service/userprofile/optional-feature/segment-leaderboard"},
+	"userProfile.stravaSegments":        {"GET", "/userprofile-service/userprofile/optional-feature/strava-segments"},
+	"userFocus.focus":                   {"GET", "/userfocus-service/focus"},
+	"userFocus.dashboard":               {"GET", "/userfocus-service/dashboard"},
+	"userFocus.suggested":               {"GET", "/userfocus-service/focus/suggestedFocuses"},
+	"userFocus.availablePrimaryStats":   {"GET", "/userfocus-service/dashboard/availablePrimaryStats"},
+	"userSummary.dailyStress":           {"GET", "/usersummary-service/stats/stress/daily/{start}/{end}"},
+	"userSummary.weeklyStress":          {"GET", "/usersummary-service/stats/stress/weekly/{date}/{weeks}"},
+	"userSummary.dailyHeartRate":        {"GET", "/usersummary-service/stats/heartRate/daily/{start}/{end}"},
+	"userSummary.weeklyHeartRate":       {"GET", "/usersummary-service/stats/heartRate/weekly/{date}/{weeks}"},
+	"userSummary.dailyBodyBattery":      {"GET", "/usersummary-service/stats/bodybattery/daily/{start}/{end}"},
+	"userSummary.dailyIntensity":        {"GET", "/usersummary-service/stats/im/daily/{start}/{end}"},
+	"userSummary.weeklyIntensity":       {"GET", "/usersummary-service/stats/im/weekly/{start}/{end}"},
+	"userSummary.daily":                 {"GET", "/usersummary-service/stats/daily/{start}/{end}"},
+	"userSummary.monthlySteps":          {"GET", "/usersummary-service/stats/steps/monthly/{date}/{months}"},
+	"userSummary.weeklySteps":           {"GET", "/usersummary-service/stats/steps/weekly/{date}/{weeks}"},
+	"userSummary.dailySummary":          {"GET", "/usersummary-service/usersummary/daily/{displayName}"},
+	"userSummary.dailyHydration":        {"GET", "/usersummary-service/usersummary/hydration/daily/{date}"},
+	"userSummary.logHydration":          {"PUT", "/usersummary-service/usersummary/hydration/log"},
+	"upload.file":                       {"POST", "/upload-service/upload"},
+	"weight.first":                      {"GET", "/weight-service/weight/first"},
+	"weight.dayView":                    {"GET", "/weight-service/weight/dayview/{date}"},
+	"weight.latest":                     {"GET", "/weight-service/weight/latest"},
+	"weight.range":                      {"GET", "/weight-service/weight/range/{start}/{end}"},
+	"weight.log":                        {"POST", "/weight-service/user-weight"},
+	"weight.delete":                     {"DELETE", "/weight-service/weight/{date}/byversion/{version}"},
+	"wellness.dailySleep":               {"GET", "/wellness-service/wellness/dailySleepData/{displayName}"},
+	"wellness.dailyStress":              {"GET", "/wellness-service/wellness/dailyStress/{date}"},
+	"wellness.dailyEvents":              {"GET", "/wellness-service/wellness/dailyEvents/{userUUID}"},
+	"wellness.bodyBatteryMessaging":     {"GET", "/wellness-service/wellness/bodyBattery/messagingToday"},
+	"wellness.bodyBatteryEvents":        {"GET", "/wellness-service/wellness/bodyBattery/events/{date}"},
+	"wellness.dailySummaryChart":        {"GET", "/wellness-service/wellness/dailySummaryChart"},
+	"wellness.dailyIntensity":           {"GET", "/wellness-service/wellness/daily/im/{date}"},
+	"wellness.dailySpO2":                {"GET", "/wellness-service/wellness/daily/spo2/{date}"},
+	"wellness.dailyRespiration":         {"GET", "/wellness-service/wellness/daily/respiration/{date}"},
+	"wellness.dailyFloors":              {"GET", "/wellness-service/wellness/floorsChartData/daily/{date}"},
+	"wellness.dailyHeartRate":           {"GET", "/wellness-service/wellness/dailyHeartRate"},
+	"wellness.hourlyIntensity":          {"GET", "/wellness-service/stats/hourly/im/{date}/{days}"},
+	"wellness.stepsGoal":                {"GET", "/wellness-service/wellness/wellness-goals/consolidated/steps/{date}"},
+	"wellness.pushesGoal":               {"GET", "/wellness-service/wellness/wellness-goals/consolidated/pushes/{date}"},
+}
+
+// LookupEndpoint returns the endpoint this package knows by name, see
+// Client.Call.
+func LookupEndpoint(name string) (Endpoint, bool) {
+	e, ok := endpoints[name]
+	return e, ok
+}
+
+// WithEndpoints gives names to more endpoints for Client.Call. They are
+// used in metrics and matched by cache rules like the built-in ones, and
+// replace built-in endpoints of the same name.
+func WithEndpoints(extra map[string]Endpoint) ClientOpt {
+	return func(co *clientOpts) {
+		if co.Endpoints == nil {
+			co.Endpoints = maps.Clone(endpoints)
+		}
+		maps.Copy(co.Endpoints, extra)
+	}
+}
+
+// templates returns the templates of the endpoints, for naming requests in
+// metrics and cache rules.
+func templates(named map[string]Endpoint) *rt.Templates {
+	templates := make([]string, 0, len(named))
+	for _, e := range named {
+		templates = append(templates, e.Template)
+	}
+	return rt.NewTemplates(templates...)
+}
+
+// Call sends a request to the named endpoint, see LookupEndpoint and
+// WithEndpoints, with args for the placeholders in its template. Metrics and
+// cache rules see the template instead of the path. Body and out work as for
+// Request.
+func (c *Client) Call(ctx context.Context, name string, args []any, params url.Values, body, out any) error {
+	e, ok := c.endpoints[name]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrUnknownEndpoint, name)
+	}
+	p, err := e.Path(args...)
+	if err != nil {
+		return err
+	}
+	if len(params) > 0 {
+		p += "?" + params.Encode()
+	}
+	ctx = rt.WithEndpoint(ctx, e.Template)
+	if strings.EqualFold(e.Method, "GET") {
+		return c.Get(ctx, p, nil, out)
+	}
+	return c.Request(ctx, e.Method, p, body, out)
+}
@@ -0,0 +1,276 @@
+package garmin
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+type recordingObserver struct{ endpoints []string }
+
+func (ro *recordingObserver) RequestStart(*RequestEvent) {}
+func (ro *recordingObserver) RequestEnd(ev *RequestEvent) {
+	ro.endpoints = append(ro.endpoints, ev.Method+" "+ev.Endpoint)
+}
+func (ro *recordingObserver) TokenRefresh(*RefreshEvent) {}
+
+func TestEndpointPath(t *testing.T) {
+	e, _ := LookupEndpoint("badge.earnedForActivity")
+	if got := strings.Join(e.Placeholders(), ","); got != "userUUID,activityID" {
+		t.Errorf("placeholders: got %s", got)
+	}
+	p, err := e.Path("a b", 42)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p != "/badge-service/badge/a%20b/earned/activity/42" {
+		t.Errorf("path: got %s", p)
+	}
+	daily, _ := LookupEndpoint("userSummary.daily")
+	p, _ = daily.Path(time.Date(2024, 8, 10, 0, 0, 0, 0, time.UTC), NewCalendarDate(2024, 8, 16))
+	if p != "/usersummary-service/stats/daily/2024-08-10/2024-08-16" {
+		t.Errorf("dates: got %s", p)
+	}
+	if _, err = e.Path(1); err == nil {
+		t.Error("expected an error for missing arguments")
+	}
+	for name, e := range endpoints {
+		if e.Method != http.MethodGet && e.Method != http.MethodPost && e.Method != http.MethodPut && e.Method != http.MethodDelete {
+			t.Errorf("%s: method %q", name, e.Method)
+		}
+		if !strings.HasPrefix(e.Template, "/") {
+			t.Errorf("%s: template %q", name, e.Template)
+		}
+	}
+}
+
+func TestRawRequests(t *testing.T) {
+	transport := &fakeTransport{fn: func(req *http.Request) (*http.Response, error) {
+		var body []byte
+		if req.Body != nil {
+			body, _ = io.ReadAll(req.Body)
+		}
+		switch req.URL.Path {
+		case "/missing":
+			return jsonResponse(req, http.StatusNotFound, `not here`), nil
+		case "/empty":
+			return jsonResponse(req, http.StatusNoContent, ``), nil
+		case "/accepted":
+			return jsonResponse(req, http.StatusAccepted, `{"method": "`+req.Method+`"}`), nil
+		}
+		return jsonResponse(req, http.StatusOK, `{"method": "`+req.Method+`", "query": "`+req.URL.RawQuery+
+			`", "type": "`+req.Header.Get("Content-Type")+`", "body": `+string(bytes.TrimSpace(orJSONNull(body)))+`}`), nil
+	}}
+	observer := new(recordingObserver)
+	client := NewClient(WithTransport(transport), WithObserver(observer))
+	ctx := context.Background()
+	type echo struct {
+		Method string         `json:"method"`
+		Query  string         `json:"query"`
+		Type   string         `json:"type"`
+		Body   map[string]any `json:"body"`
+	}
+
+	var out echo
+	if err := client.Get(ctx, "/new-service/thing?a=1", url.Values{"b": {"2"}}, &out); err != nil {
+		t.Fatal(err)
+	}
+	if out.Method != "GET" || out.Query != "a=1&b=2" {
+		t.Errorf("get: %+v", out)
+	}
+
+	out = echo{}
+	if err := client.Request(ctx, http.MethodPut, "/new-service/thing", map[string]any{"x": 1}, &out); err != nil {
+		t.Fatal(err)
+	}
+	if out.Method != "PUT" || out.Type != "application/json" || out.Body["x"] != 1.0 {
+		t.Errorf("put: %+v", out)
+	}
+
+	var raw bytes.Buffer
+	if err := client.Request(ctx, http.MethodDelete, "/new-service/thing", nil, &raw); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(raw.String(), `"method": "DELETE"`) {
+		t.Errorf("raw output: %s", raw.String())
+	}
+
+	if err := client.Request(ctx, http.MethodPost, "/empty", nil, &out); err != nil {
+		t.Errorf("no content: %v", err)
+	}
+	out = echo{}
+	if err := client.Get(ctx, "/accepted", nil, &out); err != nil || out.Method != "GET" {
+		t.Errorf("accepted: %+v, %v", out, err)
+	}
+	if err := client.Get(ctx, "/empty", nil, &out); err != nil {
+		t.Errorf("get no content: %v", err)
+	}
+	var apiErr *APIError
+	if err := client.Get(ctx, "/missing", nil, &out); !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusNotFound || string(apiErr.Body) != "not here" {
+		t.Errorf("missing: got %v", err)
+	}
+
+	if err := client.Call(ctx, "activity.details", []any{123}, url.Values{"maxChartSize": {"100"}}, nil, &out); err != nil {
+		t.Fatal(err)
+	}
+	if out.Query != "maxChartSize=100" {
+		t.Errorf("call: %+v", out)
+	}
+	if err := client.Call(ctx, "no.such", nil, nil, nil, nil); !errors.Is(err, ErrUnknownEndpoint) {
+		t.Errorf("want %v, got %v", ErrUnknownEndpoint, err)
+	}
+	if got := observer.endpoints[len(observer.endpoints)-1]; got != "GET /activity-service/activity/{id}/details" {
+		t.Errorf("observed endpoint: %s", got)
+	}
+}
+
+// TestEscapedPaths checks that escaped path segments are sent escaped once.
+func TestEscapedPaths(t *testing.T) {
+	ctx := context.Background()
+	testRequests(t, []requestTest{{
+		name: "Call",
+		call: func(api *API) error {
+			return api.Gear.c.Call(ctx, "badge.earnedForActivity", []any{"a b", 42}, nil, nil, nil)
+		},
+		method: "GET",
+		path:   "/badge-service/badge/a%20b/earned/activity/42",
+	}, {
+		name: "CallParams",
+		call: func(api *API) error {
+			return api.Gear.c.Call(ctx, "badge.earnedForActivity", []any{"a b", 42}, url.Values{"x": {"1"}}, nil, nil)
+		},
+		method: "GET",
+		path:   "/badge-service/badge/a%20b/earned/activity/42?x=1",
+	}, {
+		name: "GearStats",
+		call: func(api *API) error {
+			_, err := api.Gear.Stats("a/b c")
+			return err
+		},
+		response: `{}`,
+		method:   "GET",
+		path:     "/gear-service/gear/stats/a%2Fb%20c",
+	}})
+}
+
+func orJSONNull(b []byte) []byte {
+	if len(b) == 0 {
+		return []byte("null")
+	}
+	return b
+}
+
+// TestServicePathsAreRegistered calls every service method and checks that
+// each request it sends matches an endpoint of the registry, so that metrics
+// and cache rules know all of them.
+func TestServicePathsAreRegistered(t *testing.T) {
+	// Arguments that the generated ones would be rejected for before any
+	// request is sent.
+	overrides := map[string][]any{
+		"ChallengeService.CreateAdHoc":     {&NewAdHocChallenge{TimeZone: "Europe/Helsinki"}},
+		"GearService.Update":               {&Gear{UUID: "x1"}},
+		"GearService.Retire":               {&Gear{UUID: "x1"}},
+		"GoalService.Progress":             {&Goal{GoalType: GoalSteps, StartDate: NewCalendarDate(2024, 8, 1)}},
+		"WeightService.UpdateWeight":       {80.0, WeightUnitKg},
+		"WeightService.LogWeight":          {80.0, WeightUnitKg, requestTestTime},
+		"WeightService.LogBodyComposition": {&BodyComposition{Time: requestTestTime, Weight: 80, Unit: WeightUnitKg}},
+	}
+	methods := make(map[string][]string)
+	for _, e := range endpoints {
+		methods[e.Template] = append(methods[e.Template], e.Method)
+	}
+	registry := templates(endpoints)
+
+	var sent []*http.Request
+	transport := &fakeTransport{fn: func(req *http.Request) (*http.Response, error) {
+		sent = append(sent, req)
+		if strings.HasSuffix(req.URL.Path, "/userProfileBase") {
+			return jsonResponse(req, http.StatusOK, `{"displayName": "x1"}`), nil
+		}
+		return jsonResponse(req, http.StatusOK, `null`), nil
+	}}
+	api := reflect.ValueOf(NewAPI(NewClient(WithTransport(transport), WithClock(fakeClock(requestTestTime))))).Elem()
+	for i := range api.NumField() {
+		svc := api.Field(i)
+		for j := range svc.NumMethod() {
+			m := svc.Method(j)
+			name := svc.Type().Elem().Name() + "." + svc.Type().Method(j).Name
+			args := overrides[name]
+			if args == nil {
+				for k := range m.Type().NumIn() {
+					args = append(args, testArg(m.Type().In(k)).Interface())
+				}
+			}
+			in := make([]reflect.Value, len(args))
+			for k, a := range args {
+				in[k] = reflect.ValueOf(a)
+			}
+			sent = nil
+			if !callImplemented(m, in) {
+				continue
+			}
+			if len(sent) == 0 {
+				t.Errorf("%s sent no request", name)
+			}
+			for _, req := range sent {
+				p := req.URL.EscapedPath()
+				tmpl := registry.Match(p)
+				if !slices.Contains(methods[tmpl], req.Method) {
+					t.Errorf("%s: %s %s is not in the registry", name, req.Method, p)
+				}
+			}
+		}
+	}
+}
+
+// callImplemented calls m, and reports false when it panics because it is
+// not implemented yet.
+func callImplemented(m reflect.Value, in []reflect.Value) (ok bool) {
+	defer func() {
+		if r := recover(); r != nil {
+			if r != "not implemented" {
+				panic(r)
+			}
+			ok = false
+		}
+	}()
+	m.Call(in)
+	return true
+}
+
+// testArg returns a value of type typ that service methods accept.
+func testArg(typ reflect.Type) reflect.Value {
+	v := reflect.New(typ).Elem()
+	switch {
+	case typ == reflect.TypeFor[time.Time]():
+		v.Set(reflect.ValueOf(requestTestTime))
+		return v
+	case typ == reflect.TypeFor[time.Month]():
+		v.SetInt(int64(time.August))
+		return v
+	}
+	switch typ.Kind() {
+	case reflect.String:
+		v.SetString("x1")
+	case reflect.Int, reflect.Int64:
+		v.SetInt(1)
+	case reflect.Float64:
+		v.SetFloat(1)
+	case reflect.Bool:
+		v.SetBool(true)
+	case reflect.Pointer:
+		v.Set(reflect.New(typ.Elem()))
+	case reflect.Slice:
+		v.Set(reflect.MakeSlice(typ, 1, 1))
+		v.Index(0).Set(testArg(typ.Elem()))
+	}
+	return v
+}
@@ -0,0 +1,52 @@
+//go:build !unix
+
+package garmin
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// lockTimeout is how long lockFile waits for a lock held by another process.
+var lockTimeout = 10 * time.Second
+
+// lockFile falls back to creating the lock file exclusively and retrying
+// until it is gone, or lockTimeout has passed. Readers and writers are treated
+// the same. A lock older than staleLock is assumed to belong to a process that
+// died.
+func lockFile(name string, exclusive bool) (unlock func(), err error) {
+	const (
+		staleLock  = 30 * time.Second
+		maxBackoff = 200 * time.Millisecond
+	)
+	if !exclusive {
+		if _, err = os.Stat(filepath.Dir(name)); err != nil {
+			return nil, err
+		}
+	}
+	lock := name + ".held"
+	deadline := time.Now().Add(lockTimeout)
+	backoff := 5 * time.Millisecond
+	for {
+		f, err := os.OpenFile(lock, os.O_RDWR|os.O_CREATE|os.O_EXCL, 0600)
+		if err == nil {
+			_ = f.Close()
+			return func() { _ = os.Remove(lock) }, nil
+		}
+		if !errors.Is(err, os.ErrExist) {
+			return nil, err
+		}
+		if fi, err := os.Stat(lock); err == nil && time.Since(fi.ModTime()) > staleLock {
+			_ = os.Remove(lock)
+			continue
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("timed out after %s waiting for lock %s", lockTimeout, lock)
+		}
+		time.Sleep(backoff)
+		backoff = min(2*backoff, maxBackoff)
+	}
+}
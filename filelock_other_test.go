@@ -0,0 +1,28 @@
+//go:build !unix
+
+package garmin
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestLockFileTimeout(t *testing.T) {
+	defer func(d time.Duration) { lockTimeout = d }(lockTimeout)
+	lockTimeout = 50 * time.Millisecond
+	name := filepath.Join(t.TempDir(), "tokens.lock")
+	unlock, err := lockFile(name, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = lockFile(name, true); err == nil {
+		t.Fatal("second lock was taken while the first is held")
+	}
+	unlock()
+	unlock, err = lockFile(name, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	unlock()
+}
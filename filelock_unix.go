@@ -0,0 +1,30 @@
+//go:build unix
+
+package garmin
+
+import (
+	"os"
+	"syscall"
+)
+
+// lockFile takes an advisory lock on name, exclusive for writers and shared
+// for readers. Readers don't create the lock file, so a missing cache
+// directory is reported as os.ErrNotExist.
+func lockFile(name string, exclusive bool) (unlock func(), err error) {
+	flag, how := os.O_RDONLY, syscall.LOCK_SH
+	if exclusive {
+		flag, how = os.O_RDWR|os.O_CREATE, syscall.LOCK_EX
+	}
+	f, err := os.OpenFile(name, flag, 0600)
+	if err != nil {
+		return nil, err
+	}
+	if err = syscall.Flock(int(f.Fd()), how); err != nil {
+		_ = f.Close()
+		return nil, err
+	}
+	return func() {
+		_ = syscall.Flock(int(f.Fd()), syscall.LOCK_UN)
+		_ = f.Close()
+	}, nil
+}
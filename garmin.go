@@ -7,38 +7,58 @@ import (
 
 // API holds all the services.
 type API struct {
-	Activity       *ActivityService
-	ActivityList   *ActivityListService
-	Course         *CourseService
-	Device         *DeviceService
-	FitnessAge     *FitnessAgeService
-	FitnessStats   *FitnessStatsService
-	PersonalRecord *PersonalRecordService
-	Sleep          *SleepService
-	UserFocus      *UserFocusService
-	UserProfile    *UserProfileService
-	UserSummary    *UserSummaryService
-	Weight         *WeightService
-	Wellness       *WellnessService
+	Activity          *ActivityService
+	ActivityList      *ActivityListService
+	Badge             *BadgeService
+	Biometric         *BiometricService
+	BloodPressure     *BloodPressureService
+	Challenge         *ChallengeService
+	Course            *CourseService
+	Device            *DeviceService
+	FitnessAge        *FitnessAgeService
+	FitnessStats      *FitnessStatsService
+	Gear              *GearService
+	Goal              *GoalService
+	HRV               *HRVService
+	Performance       *PerformanceService
+	PersonalRecord    *PersonalRecordService
+	Sleep             *SleepService
+	TrainingReadiness *TrainingReadinessService
+	TrainingStatus    *TrainingStatusService
+	UserFocus         *UserFocusService
+	UserProfile       *UserProfileService
+	UserSummary       *UserSummaryService
+	Weight            *WeightService
+	Wellness          *WellnessService
 }
 
 // NewAPI creates a new API struct.
 func NewAPI(client *Client) *API {
 	s := service{c: client}
 	return &API{
-		Activity:       (*ActivityService)(&s),
-		ActivityList:   (*ActivityListService)(&s),
-		Course:         (*CourseService)(&s),
-		Device:         (*DeviceService)(&s),
-		FitnessAge:     (*FitnessAgeService)(&s),
-		FitnessStats:   (*FitnessStatsService)(&s),
-		PersonalRecord: (*PersonalRecordService)(&s),
-		Sleep:          (*SleepService)(&s),
-		UserFocus:      (*UserFocusService)(&s),
-		UserProfile:    (*UserProfileService)(&s),
-		UserSummary:    (*UserSummaryService)(&s),
-		Weight:         (*WeightService)(&s),
-		Wellness:       (*WellnessService)(&s),
+		Activity:          (*ActivityService)(&s),
+		ActivityList:      (*ActivityListService)(&s),
+		Badge:             (*BadgeService)(&s),
+		Biometric:         (*BiometricService)(&s),
+		BloodPressure:     (*BloodPressureService)(&s),
+		Challenge:         (*ChallengeService)(&s),
+		Course:            (*CourseService)(&s),
+		Device:            (*DeviceService)(&s),
+		FitnessAge:        (*FitnessAgeService)(&s),
+		FitnessStats:      (*FitnessStatsService)(&s),
+		Gear:              (*GearService)(&s),
+		Goal:              (*GoalService)(&s),
+		HRV:               (*HRVService)(&s),
+		Performance:       (*PerformanceService)(&s),
+		PersonalRecord:    (*PersonalRecordService)(&s),
+		Sleep:             (*SleepService)(&s),
+		TrainingReadiness: (*TrainingReadinessService)(&s),
+		TrainingStatus:    (*TrainingStatusService)(&s),
+		UserFocus:         (*UserFocusService)(&s),
+		UserProfile:       (*UserProfileService)(&s),
+		UserSummary:       (*UserSummaryService)(&s),
+		Weight:            (*WeightService)(&s),
+		Wellness:          (*WellnessService)(&s),
 	}
 }
 
@@ -52,9 +72,18 @@ type Clock interface {
 	Now() time.Time
 }
 
+// TimerClock is a Clock that can also wait for time to pass on it, the way
+// time.After does. The client waits on it instead of the wall clock for
+// WithRateLimit, so that a fake clock doesn't make requests wait in real time.
+type TimerClock interface {
+	Clock
+	After(d time.Duration) <-chan time.Time
+}
+
 type clock struct{}
 
-func (*clock) Now() time.Time { return time.Now() }
+func (*clock) Now() time.Time                         { return time.Now() }
+func (*clock) After(d time.Duration) <-chan time.Time { return time.After(d) }
 
 type UnixTS time.Time
 
@@ -101,3 +130,207 @@ func (uts UnixTS) Second() int                     { return time.Time(uts).Secon
 func (uts UnixTS) Nanosecond() int                 { return time.Time(uts).Nanosecond() }
 func (uts UnixTS) YearDay() int                    { return time.Time(uts).YearDay() }
 func (uts UnixTS) Format(format string) string     { return time.Time(uts).Format(format) }
+
+// Layouts seen in Garmin responses. When parsing, fractional seconds are
+// accepted after the seconds field even though the layouts don't list them,
+// so "2024-08-16T06:39:43.0" and "2024-08-16T06:39:43.000" are both covered.
+var garminTimeLayouts = []string{
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05Z0700",
+	"2006-01-02 15:04:05Z0700",
+	"2006-01-02T15:04",
+	time.DateOnly,
+}
+
+const (
+	garminTimeFormat = "2006-01-02T15:04:05.0"
+	calendarFormat   = time.DateOnly
+)
+
+// parseGarminTime parses one of the layouts in garminTimeLayouts. Strings that
+// have no zone information are interpreted in loc.
+func parseGarminTime(s string, loc *time.Location) (time.Time, error) {
+	var firstErr error
+	for _, layout := range garminTimeLayouts {
+		t, err := time.ParseInLocation(layout, s, loc)
+		if err == nil {
+			return t, nil
+		}
+		if firstErr == nil {
+			firstErr = err
+		}
+	}
+	return time.Time{}, firstErr
+}
+
+// unquoteJSON returns the contents of a JSON string, the text of a JSON
+// number, or ok=false for null.
+func unquoteJSON(b []byte) (s string, ok bool, err error) {
+	if string(b) == "null" {
+		return "", false, nil
+	}
+	if len(b) > 0 && b[0] == '"' {
+		s, err = strconv.Unquote(string(b))
+		return s, err == nil, err
+	}
+	return string(b), true, nil
+}
+
+// GarminGMTTime is a timestamp that Garmin sends in UTC without any zone
+// information, for example "2024-08-16T13:47:04.0". Millisecond epoch numbers
+// are also accepted.
+type GarminGMTTime time.Time
+
+func (gt *GarminGMTTime) UnmarshalJSON(b []byte) error {
+	s, ok, err := unquoteJSON(b)
+	if err != nil || !ok {
+		return err
+	}
+	return gt.UnmarshalText([]byte(s))
+}
+
+func (gt *GarminGMTTime) UnmarshalText(b []byte) error {
+	if len(b) == 0 {
+		*gt = GarminGMTTime{}
+		return nil
+	}
+	if i, err := strconv.ParseInt(string(b), 10, 64); err == nil {
+		*gt = GarminGMTTime(time.UnixMilli(i).UTC())
+		return nil
+	}
+	t, err := parseGarminTime(string(b), time.UTC)
+	if err != nil {
+		return err
+	}
+	*gt = GarminGMTTime(t.UTC())
+	return nil
+}
+
+func (gt GarminGMTTime) MarshalText() ([]byte, error) {
+	if gt.IsZero() {
+		return []byte{}, nil
+	}
+	return []byte(gt.Time().Format(garminTimeFormat)), nil
+}
+
+// Time returns the timestamp in UTC.
+func (gt GarminGMTTime) Time() time.Time                 { return time.Time(gt).UTC() }
+func (gt GarminGMTTime) In(loc *time.Location) time.Time { return time.Time(gt).In(loc) }
+func (gt GarminGMTTime) IsZero() bool                    { return time.Time(gt).IsZero() }
+func (gt GarminGMTTime) Format(format string) string     { return gt.Time().Format(format) }
+func (gt GarminGMTTime) String() string                  { return gt.Time().String() }
+
+// GarminLocalTime is a wall clock time in the user's (or the activity's) time
+// zone, for example "2024-08-16 06:39:43". Garmin does not say which zone it is
+// in, so the value has to be resolved with In or InZone before it can be
+// compared with other times.
+type GarminLocalTime time.Time
+
+func (lt *GarminLocalTime) UnmarshalJSON(b []byte) error {
+	s, ok, err := unquoteJSON(b)
+	if err != nil || !ok {
+		return err
+	}
+	return lt.UnmarshalText([]byte(s))
+}
+
+func (lt *GarminLocalTime) UnmarshalText(b []byte) error {
+	if len(b) == 0 {
+		*lt = GarminLocalTime{}
+		return nil
+	}
+	// The wall clock is kept in UTC and only reinterpreted by In.
+	t, err := parseGarminTime(string(b), time.UTC)
+	if err != nil {
+		return err
+	}
+	*lt = GarminLocalTime(t)
+	return nil
+}
+
+func (lt GarminLocalTime) MarshalText() ([]byte, error) {
+	if lt.IsZero() {
+		return []byte{}, nil
+	}
+	return []byte(time.Time(lt).Format(garminTimeFormat)), nil
+}
+
+// In returns the wall clock time interpreted in loc. Times that fall into a
+// DST gap or overlap are resolved the same way time.Date resolves them.
+func (lt GarminLocalTime) In(loc *time.Location) time.Time {
+	t := time.Time(lt)
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), loc)
+}
+
+// InZone is like In but takes an IANA time zone name such as the ones found in
+// TimeZoneUnit.TimeZone.
+func (lt GarminLocalTime) InZone(name string) (time.Time, error) {
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return lt.In(loc), nil
+}
+
+func (lt GarminLocalTime) IsZero() bool { return time.Time(lt).IsZero() }
+
+// Format formats the wall clock time. Zone related verbs are meaningless.
+func (lt GarminLocalTime) Format(format string) string { return time.Time(lt).Format(format) }
+func (lt GarminLocalTime) String() string              { return lt.Format(garminTimeFormat) }
+
+// CalendarDate is a day without a time of day, for example "2024-08-16".
+type CalendarDate time.Time
+
+func NewCalendarDate(year int, month time.Month, day int) CalendarDate {
+	return CalendarDate(time.Date(year, month, day, 0, 0, 0, 0, time.UTC))
+}
+
+func (cd *CalendarDate) UnmarshalJSON(b []byte) error {
+	s, ok, err := unquoteJSON(b)
+	if err != nil || !ok {
+		return err
+	}
+	return cd.UnmarshalText([]byte(s))
+}
+
+func (cd *CalendarDate) UnmarshalText(b []byte) error {
+	if len(b) == 0 {
+		*cd = CalendarDate{}
+		return nil
+	}
+	// Some endpoints send a full timestamp where only the date is relevant.
+	t, err := parseGarminTime(string(b), time.UTC)
+	if err != nil {
+		return err
+	}
+	*cd = NewCalendarDate(t.Date())
+	return nil
+}
+
+func (cd CalendarDate) MarshalText() ([]byte, error) {
+	if cd.IsZero() {
+		return []byte{}, nil
+	}
+	return []byte(cd.String()), nil
+}
+
+// In returns midnight at the start of the day in loc.
+func (cd CalendarDate) In(loc *time.Location) time.Time {
+	return time.Date(cd.Year(), cd.Month(), cd.Day(), 0, 0, 0, 0, loc)
+}
+
+func (cd CalendarDate) Date() (int, time.Month, int) { return time.Time(cd).Date() }
+func (cd CalendarDate) Year() int                    { return time.Time(cd).Year() }
+func (cd CalendarDate) Month() time.Month            { return time.Time(cd).Month() }
+func (cd CalendarDate) Day() int                     { return time.Time(cd).Day() }
+func (cd CalendarDate) Weekday() time.Weekday        { return time.Time(cd).Weekday() }
+func (cd CalendarDate) AddDate(y, m, d int) CalendarDate {
+	return CalendarDate(time.Time(cd).AddDate(y, m, d))
+}
+func (cd CalendarDate) Before(d CalendarDate) bool { return time.Time(cd).Before(time.Time(d)) }
+func (cd CalendarDate) After(d CalendarDate) bool  { return time.Time(cd).After(time.Time(d)) }
+func (cd CalendarDate) Equal(d CalendarDate) bool  { return time.Time(cd).Equal(time.Time(d)) }
+func (cd CalendarDate) IsZero() bool               { return time.Time(cd).IsZero() }
+func (cd CalendarDate) String() string             { return time.Time(cd).Format(calendarFormat) }
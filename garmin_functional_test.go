@@ -84,3 +84,131 @@ func TestFunctional_Activities(t *testing.T) {
 		fmt.Printf("%+v\n", a)
 	}
 }
+
+func TestFunctional_TrainingStatus(t *testing.T) {
+	t.Skip()
+	status, err := testapi(t).TrainingStatus.Aggregated(time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	fmt.Printf("%+v\n", status.MostRecentTrainingStatus.Primary())
+}
+
+func TestFunctional_HRV(t *testing.T) {
+	t.Skip()
+	hrv, err := testapi(t).HRV.Daily(time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	fmt.Printf("%+v\n", hrv.HRVSummary)
+}
+
+func TestFunctional_TrainingReadiness(t *testing.T) {
+	t.Skip()
+	readiness, err := testapi(t).TrainingReadiness.Morning(time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	fmt.Printf("%+v\n", readiness)
+}
+
+func TestFunctional_Performance(t *testing.T) {
+	t.Skip()
+	api := testapi(t)
+	es, err := api.Performance.EnduranceScore(time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	fmt.Printf("%+v\n", es)
+	hs, err := api.Performance.HillScore(time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	fmt.Printf("%+v\n", hs)
+}
+
+func TestFunctional_Biometric(t *testing.T) {
+	t.Skip()
+	api := testapi(t)
+	lt, err := api.Biometric.LactateThreshold()
+	if err != nil {
+		t.Fatal(err)
+	}
+	fmt.Printf("%+v\n", lt)
+	zones, err := api.Biometric.HeartRateZones()
+	if err != nil {
+		t.Fatal(err)
+	}
+	fmt.Printf("%+v\n", zones)
+}
+
+func TestFunctional_Gear(t *testing.T) {
+	t.Skip()
+	api := testapi(t)
+	profile, err := api.UserProfile.UserProfileBase()
+	if err != nil {
+		t.Fatal(err)
+	}
+	gear, err := api.Gear.List(int64(profile.UserProfilePk))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, g := range gear {
+		stats, err := api.Gear.Stats(g.UUID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		fmt.Printf("%s: %+v\n", g.DisplayName, stats)
+	}
+}
+
+func TestFunctional_HealthMetrics(t *testing.T) {
+	t.Skip()
+	api := testapi(t)
+	spo2, err := api.Wellness.DailySpO2(time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	fmt.Printf("%+v\n", spo2)
+	hydration, err := api.UserSummary.DailyHydration(time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	fmt.Printf("%+v\n", hydration)
+}
+
+func TestFunctional_BloodPressure(t *testing.T) {
+	t.Skip()
+	end := time.Now()
+	bpr, err := testapi(t).BloodPressure.Range(end.AddDate(0, -1, 0), end)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fmt.Printf("%+v\n", bpr.Readings())
+}
+
+func TestFunctional_DailySummary(t *testing.T) {
+	t.Skip()
+	summary, err := testapi(t).UserSummary.Daily(time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	fmt.Printf("%+v\n", summary)
+}
+
+func TestFunctional_Challenges(t *testing.T) {
+	t.Skip()
+	api := testapi(t)
+	challenges, err := api.Challenge.BadgeChallenges(BadgeChallengesInProgress, 1, 20)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, c := range challenges {
+		fmt.Printf("%s: %.0f%%\n", c.Name, c.Progress())
+	}
+	goals, err := api.Goal.Goals(GoalsActive, 1, 30)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fmt.Printf("%+v\n", goals)
+}
@@ -1,7 +1,17 @@
 package garmin
 
 import (
+	"bytes"
+	"encoding/json"
+	"io"
 	"log/slog"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jylitalo/go-garmin/internal/rt"
 )
 
 func init() {
@@ -9,3 +19,176 @@ func init() {
 	slog.SetLogLoggerLevel(slog.LevelDebug)
 	slog.SetLogLoggerLevel(slog.LevelInfo)
 }
+
+func TestGarminTimes(t *testing.T) {
+	t.Run("GMT", func(t *testing.T) {
+		exp := time.Date(2024, time.August, 16, 13, 47, 4, 0, time.UTC)
+		for _, in := range []string{
+			`"2024-08-16T13:47:04.0"`,
+			`"2024-08-16T13:47:04.000"`,
+			`"2024-08-16 13:47:04"`,
+			`"2024-08-16T13:47:04Z"`,
+			`"2024-08-16T15:47:04+02:00"`,
+			`1723816024000`,
+		} {
+			var gt GarminGMTTime
+			if err := json.Unmarshal([]byte(in), &gt); err != nil {
+				t.Fatalf("%s: %v", in, err)
+			}
+			if !gt.Time().Equal(exp) {
+				t.Errorf("%s: got %v, want %v", in, gt.Time(), exp)
+			}
+		}
+	})
+	t.Run("Local", func(t *testing.T) {
+		var a Activity
+		err := json.Unmarshal([]byte(`{
+			"timeZoneUnitDTO": {"timeZone": "America/Los_Angeles"},
+			"summaryDTO": {"startTimeLocal": "2024-03-10T03:30:00.0"}
+		}`), &a)
+		if err != nil {
+			t.Fatal(err)
+		}
+		start, err := a.StartTime()
+		if err != nil {
+			t.Fatal(err)
+		}
+		// 03:30 on the day DST starts is already PDT (UTC-7).
+		exp := time.Date(2024, time.March, 10, 10, 30, 0, 0, time.UTC)
+		if !start.Equal(exp) {
+			t.Errorf("got %v, want %v", start.UTC(), exp)
+		}
+		if start.Location().String() != "America/Los_Angeles" {
+			t.Errorf("got location %v", start.Location())
+		}
+	})
+	t.Run("NoTimeZone", func(t *testing.T) {
+		var a Activity
+		err := json.Unmarshal([]byte(`{"summaryDTO": {"startTimeGMT": "2024-03-10T10:30:00.0", "startTimeLocal": "2024-03-10T03:30:00.0"}}`), &a)
+		if err != nil {
+			t.Fatal(err)
+		}
+		start, err := a.StartTime()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if exp := time.Date(2024, time.March, 10, 10, 30, 0, 0, time.UTC); !start.Equal(exp) {
+			t.Errorf("got %v, want %v", start, exp)
+		}
+		a.SummaryDTO.StartTimeGMT = GarminGMTTime{}
+		if _, err = a.StartTime(); err == nil {
+			t.Error("local time without a time zone should fail")
+		}
+	})
+	t.Run("CalendarDate", func(t *testing.T) {
+		var cal Calendar
+		err := json.Unmarshal([]byte(`{"startDate": "2024-08-01", "endDate": null, "calendarItems": [{"date": "2024-08-16"}]}`), &cal)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if s := cal.StartDate.String(); s != "2024-08-01" {
+			t.Errorf("got %q", s)
+		}
+		if !cal.EndDate.IsZero() {
+			t.Errorf("null end date should be zero, got %v", cal.EndDate)
+		}
+		b, err := json.Marshal(cal.CalendarItems[0].Date)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(b) != `"2024-08-16"` {
+			t.Errorf("got %s", b)
+		}
+	})
+}
+
+// fakeTransport answers every request with fn.
+type fakeTransport struct {
+	base http.RoundTripper
+	fn   func(*http.Request) (*http.Response, error)
+}
+
+func (ft *fakeTransport) Wrap(rt http.RoundTripper) rt.RoundTripper {
+	ft.base = rt
+	return ft
+}
+
+func (ft *fakeTransport) Unwrap() http.RoundTripper { return ft.base }
+
+func (ft *fakeTransport) RoundTrip(req *http.Request) (*http.Response, error) { return ft.fn(req) }
+
+type fakeClock time.Time
+
+func (fc fakeClock) Now() time.Time { return time.Time(fc) }
+
+func jsonResponse(req *http.Request, status int, body string) *http.Response {
+	return &http.Response{
+		StatusCode: status,
+		Status:     http.StatusText(status),
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(body)),
+		Request:    req,
+	}
+}
+
+// requestTest is a call that sends one request, and the request it should
+// send.
+type requestTest struct {
+	name string
+	call func(api *API) error
+	// response is the JSON body answered with 200 OK, or 204 No Content when
+	// empty.
+	response string
+	method   string
+	path     string
+	// body is the JSON the request should send, empty for no body.
+	body string
+}
+
+// requestTestTime is the time of the clock used by testRequests.
+var requestTestTime = time.Date(2024, 8, 16, 7, 30, 0, 0, time.UTC)
+
+// testRequests runs each call and checks the method, path and body of the
+// request it sends.
+func testRequests(t *testing.T, tests []requestTest) {
+	t.Helper()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var sent []string
+			var body []byte
+			transport := &fakeTransport{fn: func(req *http.Request) (*http.Response, error) {
+				sent = append(sent, req.Method+" "+req.URL.RequestURI())
+				if req.Body != nil {
+					body, _ = io.ReadAll(req.Body)
+				}
+				if len(tt.response) == 0 {
+					return jsonResponse(req, http.StatusNoContent, ``), nil
+				}
+				return jsonResponse(req, http.StatusOK, tt.response), nil
+			}}
+			api := NewAPI(NewClient(WithTransport(transport), WithClock(fakeClock(requestTestTime))))
+			if err := tt.call(api); err != nil {
+				t.Fatal(err)
+			}
+			if want := tt.method + " " + tt.path; len(sent) != 1 || sent[0] != want {
+				t.Fatalf("sent %v, want %s", sent, want)
+			}
+			if !sameJSON(body, []byte(tt.body)) {
+				t.Errorf("body:\n got %s\nwant %s", body, tt.body)
+			}
+		})
+	}
+}
+
+// sameJSON reports whether a and b are both empty or hold the same JSON
+// value.
+func sameJSON(a, b []byte) bool {
+	if len(bytes.TrimSpace(a)) == 0 || len(bytes.TrimSpace(b)) == 0 {
+		return len(bytes.TrimSpace(a)) == len(bytes.TrimSpace(b))
+	}
+	var va, vb any
+	if json.Unmarshal(a, &va) != nil || json.Unmarshal(b, &vb) != nil {
+		return false
+	}
+	return reflect.DeepEqual(va, vb)
+}
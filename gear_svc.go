@@ -0,0 +1,179 @@
+package garmin
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/jylitalo/go-garmin/units"
+)
+
+type GearService service
+
+type GearType string
+
+const (
+	GearTypeShoes GearType = "Shoes"
+	GearTypeBike  GearType = "Bike"
+	GearTypeOther GearType = "Other"
+)
+
+type GearStatus string
+
+const (
+	GearStatusActive  GearStatus = "active"
+	GearStatusRetired GearStatus = "retired"
+)
+
+type Gear struct {
+	GearPk          int64            `json:"gearPk,omitempty"`
+	UUID            string           `json:"uuid,omitempty"`
+	UserProfilePk   int64            `json:"userProfilePk,omitempty"`
+	GearMakeName    string           `json:"gearMakeName"`
+	GearModelName   string           `json:"gearModelName"`
+	GearTypeName    GearType         `json:"gearTypeName"`
+	GearStatusName  GearStatus       `json:"gearStatusName"`
+	DisplayName     string           `json:"displayName"`
+	CustomMakeModel string           `json:"customMakeModel"`
+	ImageNameLarge  *string          `json:"imageNameLarge"`
+	ImageNameMedium *string          `json:"imageNameMedium"`
+	ImageNameSmall  *string          `json:"imageNameSmall"`
+	DateBegin       GarminLocalTime  `json:"dateBegin"`
+	DateEnd         *GarminLocalTime `json:"dateEnd"`
+	// MaximumMeters is the distance limit, Garmin notifies the user once
+	// the gear has been used this far. Zero means no limit.
+	MaximumMeters float64          `json:"maximumMeters"`
+	Notified      bool             `json:"notified"`
+	CreateDate    *GarminLocalTime `json:"createDate,omitempty"`
+	UpdateDate    *GarminLocalTime `json:"updateDate,omitempty"`
+}
+
+// Limit returns the distance limit, zero when there is none.
+func (g *Gear) Limit() units.Distance { return units.Distance(g.MaximumMeters) * units.Meter }
+
+// Wear returns how much of the distance limit the gear has used, 1 being
+// the whole limit. It is zero for gear without a limit.
+func (g *Gear) Wear(stats *GearStats) float64 {
+	if g.MaximumMeters <= 0 {
+		return 0
+	}
+	return stats.TotalDistance / g.MaximumMeters
+}
+
+type GearStats struct {
+	UUID string `json:"uuid"`
+	// TotalDistance in meters.
+	TotalDistance   float64 `json:"totalDistance"`
+	TotalActivities int     `json:"totalActivities"`
+	Processing      bool    `json:"processing"`
+}
+
+func (gs *GearStats) Distance() units.Distance { return units.Distance(gs.TotalDistance) * units.Meter }
+
+// GearDefault marks gear as the default for an activity type, new activities
+// of the type get it linked automatically.
+type GearDefault struct {
+	UUID           string `json:"uuid"`
+	GearPk         int64  `json:"gearPk"`
+	UserProfilePk  int64  `json:"userProfilePk"`
+	ActivityTypePk int    `json:"activityTypePk"`
+	DefaultGear    bool   `json:"defaultGear"`
+}
+
+// List returns all gear of the user, UserProfileBase has the userProfilePk.
+func (gs *GearService) List(userProfilePk int64) (res []Gear, err error) {
+	// GET https://connect.garmin.com/gear-service/gear/filterGear?userProfilePk=1234
+	params := url.Values{"userProfilePk": {strconv.FormatInt(userProfilePk, 10)}}
+	return res, gs.c.apiGet(&res, "/gear-service/gear/filterGear", params)
+}
+
+// ForActivity returns the gear linked to the activity.
+func (gs *GearService) ForActivity(activityID int64) (res []Gear, err error) {
+	// GET https://connect.garmin.com/gear-service/gear/filterGear?activityId=1234
+	params := url.Values{"activityId": {strconv.FormatInt(activityID, 10)}}
+	return res, gs.c.apiGet(&res, "/gear-service/gear/filterGear", params)
+}
+
+func (gs *GearService) Stats(uuid string) (*GearStats, error) {
+	// GET https://connect.garmin.com/gear-service/gear/stats/<uuid>
+	var stats GearStats
+	return &stats, gs.c.apiGet(&stats, "/gear-service/gear/stats/"+url.PathEscape(uuid), nil)
+}
+
+// Activities returns the activities the gear is linked to, newest first.
+func (gs *GearService) Activities(uuid string, start, limit int) (list []ListedActivity, err error) {
+	// GET https://connect.garmin.com/activitylist-service/activities/<uuid>/gear?start=0&limit=20
+	params := url.Values{"start": {strconv.Itoa(start)}, "limit": {strconv.Itoa(limit)}}
+	return list, gs.c.apiGet(&list, "/activitylist-service/activities/"+url.PathEscape(uuid)+"/gear", params)
+}
+
+// Defaults returns the default gear of each activity type.
+func (gs *GearService) Defaults(userProfilePk int64) (res []GearDefault, err error) {
+	// GET https://connect.garmin.com/gear-service/gear/user/1234/activityTypes
+	p := fmt.Sprintf("/gear-service/gear/user/%d/activityTypes", userProfilePk)
+	return res, gs.c.apiGet(&res, p, nil)
+}
+
+// Create adds new gear and returns it with its UUID.
+func (gs *GearService) Create(gear *Gear) (*Gear, error) {
+	// POST https://connect.garmin.com/gear-service/gear
+	var created Gear
+	if err := gs.c.apiSend(&created, "POST", "/gear-service/gear", gear); err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
+
+func (gs *GearService) Update(gear *Gear) (*Gear, error) {
+	// PUT https://connect.garmin.com/gear-service/gear/<uuid>
+	var updated Gear
+	if err := gs.c.apiSend(&updated, "PUT", "/gear-service/gear/"+url.PathEscape(gear.UUID), gear); err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+// Retire marks the gear retired as of now. Retired gear keeps its history
+// but can no longer be linked to activities.
+func (gs *GearService) Retire(gear *Gear) (*Gear, error) {
+	retired := *gear
+	retired.GearStatusName = GearStatusRetired
+	end := GarminLocalTime(gs.c.Clock.Now())
+	retired.DateEnd = &end
+	return gs.Update(&retired)
+}
+
+func (gs *GearService) Delete(uuid string) error {
+	// DELETE https://connect.garmin.com/gear-service/gear/<uuid>
+	return gs.c.apiSend(nil, "DELETE", "/gear-service/gear/"+url.PathEscape(uuid), nil)
+}
+
+// SetDefault makes the gear the default for activities of the type, like
+// "running", or stops it being one.
+func (gs *GearService) SetDefault(uuid, activityTypeKey string, isDefault bool) error {
+	// PUT https://connect.garmin.com/gear-service/gear/<uuid>/activityType/running/default/true
+	// DELETE https://connect.garmin.com/gear-service/gear/<uuid>/activityType/running
+	p := fmt.Sprintf("/gear-service/gear/%s/activityType/%s", url.PathEscape(uuid), url.PathEscape(activityTypeKey))
+	method := "DELETE"
+	if isDefault {
+		method, p = "PUT", p+"/default/true"
+	}
+	return gs.c.apiSend(nil, method, p, nil)
+}
+
+// Link adds the gear to the activity.
+func (gs *GearService) Link(uuid string, activityID int64) error {
+	// PUT https://connect.garmin.com/gear-service/gear/link/<uuid>/activity/1234
+	return gs.link("link", uuid, activityID)
+}
+
+// Unlink removes the gear from the activity.
+func (gs *GearService) Unlink(uuid string, activityID int64) error {
+	// PUT https://connect.garmin.com/gear-service/gear/unlink/<uuid>/activity/1234
+	return gs.link("unlink", uuid, activityID)
+}
+
+func (gs *GearService) link(op, uuid string, activityID int64) error {
+	p := fmt.Sprintf("/gear-service/gear/%s/%s/activity/%d", op, url.PathEscape(uuid), activityID)
+	return gs.c.apiSend(nil, "PUT", p, nil)
+}
@@ -0,0 +1,153 @@
+package garmin
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestGear(t *testing.T) {
+	var requests []string
+	var updated Gear
+	transport := &fakeTransport{fn: func(req *http.Request) (*http.Response, error) {
+		requests = append(requests, req.Method+" "+req.URL.Path)
+		switch req.Method + " " + req.URL.Path {
+		case "GET /gear-service/gear/filterGear":
+			if req.URL.Query().Get("userProfilePk") != "1234" {
+				break
+			}
+			return jsonResponse(req, http.StatusOK, `[{
+				"gearPk": 1, "uuid": "abc", "userProfilePk": 1234, "gearMakeName": "Hoka", "gearModelName": "Clifton 9",
+				"gearTypeName": "Shoes", "gearStatusName": "active", "displayName": "Daily trainers",
+				"dateBegin": "2024-05-01T00:00:00.0", "dateEnd": null, "maximumMeters": 800000, "notified": false
+			}]`), nil
+		case "GET /gear-service/gear/stats/abc":
+			return jsonResponse(req, http.StatusOK, `{"uuid": "abc", "totalDistance": 600000, "totalActivities": 57, "processing": false}`), nil
+		case "PUT /gear-service/gear/abc":
+			b, _ := io.ReadAll(req.Body)
+			if err := json.Unmarshal(b, &updated); err != nil {
+				return nil, err
+			}
+			return jsonResponse(req, http.StatusOK, string(b)), nil
+		case "PUT /gear-service/gear/link/abc/activity/42",
+			"PUT /gear-service/gear/abc/activityType/running/default/true",
+			"DELETE /gear-service/gear/abc/activityType/running":
+			return jsonResponse(req, http.StatusNoContent, ``), nil
+		}
+		return jsonResponse(req, http.StatusNotFound, `{}`), nil
+	}}
+	now := time.Date(2024, 8, 16, 7, 30, 0, 0, time.UTC)
+	api := NewAPI(NewClient(WithTransport(transport), WithClock(fakeClock(now))))
+
+	gear, err := api.Gear.List(1234)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(gear) != 1 || gear[0].GearTypeName != GearTypeShoes || gear[0].DateEnd != nil {
+		t.Fatalf("gear: %+v", gear)
+	}
+	stats, err := api.Gear.Stats(gear[0].UUID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if w := gear[0].Wear(stats); w != 0.75 {
+		t.Errorf("wear: %v", w)
+	}
+	if km := stats.Distance().Kilometers(); km != 600 {
+		t.Errorf("distance: %v", km)
+	}
+
+	retired, err := api.Gear.Retire(&gear[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if retired.GearStatusName != GearStatusRetired || retired.DateEnd == nil || !retired.DateEnd.In(time.UTC).Equal(now) {
+		t.Errorf("retired: %+v", retired)
+	}
+	if gear[0].GearStatusName != GearStatusActive {
+		t.Error("Retire changed its argument")
+	}
+
+	if err = api.Gear.Link("abc", 42); err != nil {
+		t.Error(err)
+	}
+	if err = api.Gear.SetDefault("abc", "running", true); err != nil {
+		t.Error(err)
+	}
+	if err = api.Gear.SetDefault("abc", "running", false); err != nil {
+		t.Error(err)
+	}
+	if err = api.Gear.Unlink("abc", 42); err == nil {
+		t.Errorf("expected an error, requests: %v", requests)
+	}
+}
+
+func TestGearRequests(t *testing.T) {
+	begin := GarminLocalTime(time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC))
+	shoes := Gear{
+		UUID: "abc", GearMakeName: "Hoka", GearModelName: "Clifton 9", GearTypeName: GearTypeShoes,
+		GearStatusName: GearStatusActive, DisplayName: "Daily trainers", DateBegin: begin, MaximumMeters: 800000,
+	}
+	const common = `"gearMakeName": "Hoka", "gearModelName": "Clifton 9", "gearTypeName": "Shoes",
+		"displayName": "Daily trainers", "customMakeModel": "", "imageNameLarge": null, "imageNameMedium": null,
+		"imageNameSmall": null, "dateBegin": "2024-05-01T00:00:00.0", "maximumMeters": 800000, "notified": false`
+	testRequests(t, []requestTest{
+		{
+			name: "Create",
+			call: func(api *API) error {
+				g := shoes
+				g.UUID = ""
+				created, err := api.Gear.Create(&g)
+				if err == nil && created.UUID != "new" {
+					err = fmt.Errorf("created gear has uuid %q", created.UUID)
+				}
+				return err
+			},
+			response: `{"uuid": "new"}`,
+			method:   "POST",
+			path:     "/gear-service/gear",
+			body:     `{` + common + `, "gearStatusName": "active", "dateEnd": null}`,
+		},
+		{
+			name:     "Retire",
+			call:     func(api *API) error { _, err := api.Gear.Retire(&shoes); return err },
+			response: `{"uuid": "abc"}`,
+			method:   "PUT",
+			path:     "/gear-service/gear/abc",
+			body:     `{"uuid": "abc", ` + common + `, "gearStatusName": "retired", "dateEnd": "2024-08-16T07:30:00.0"}`,
+		},
+		{
+			name:   "Delete",
+			call:   func(api *API) error { return api.Gear.Delete("abc") },
+			method: "DELETE",
+			path:   "/gear-service/gear/abc",
+		},
+		{
+			name:   "SetDefault",
+			call:   func(api *API) error { return api.Gear.SetDefault("abc", "running", true) },
+			method: "PUT",
+			path:   "/gear-service/gear/abc/activityType/running/default/true",
+		},
+		{
+			name:   "UnsetDefault",
+			call:   func(api *API) error { return api.Gear.SetDefault("abc", "running", false) },
+			method: "DELETE",
+			path:   "/gear-service/gear/abc/activityType/running",
+		},
+		{
+			name:   "Link",
+			call:   func(api *API) error { return api.Gear.Link("abc", 42) },
+			method: "PUT",
+			path:   "/gear-service/gear/link/abc/activity/42",
+		},
+		{
+			name:   "Unlink",
+			call:   func(api *API) error { return api.Gear.Unlink("abc", 42) },
+			method: "PUT",
+			path:   "/gear-service/gear/unlink/abc/activity/42",
+		},
+	})
+}
@@ -2,4 +2,7 @@ module github.com/jylitalo/go-garmin
 
 go 1.24.2
 
-require github.com/dghubble/oauth1 v0.7.3
+require (
+	github.com/dghubble/oauth1 v0.7.3
+	golang.org/x/crypto v0.38.0
+)
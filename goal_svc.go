@@ -0,0 +1,135 @@
+package garmin
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// GoalService has the user's goals, like weekly step or distance goals.
+type GoalService service
+
+type GoalType string
+
+const (
+	GoalSteps            GoalType = "STEPS"
+	GoalDistance         GoalType = "DISTANCE"
+	GoalIntensityMinutes GoalType = "INTENSITY_MINUTES"
+)
+
+type GoalStatus string
+
+const (
+	GoalsActive GoalStatus = "active"
+	GoalsFuture GoalStatus = "future"
+	GoalsPast   GoalStatus = "past"
+)
+
+type Goal struct {
+	GoalPk        int64    `json:"goalPk"`
+	UserProfilePk int64    `json:"userProfilePk"`
+	GoalName      string   `json:"goalName"`
+	GoalType      GoalType `json:"goalType"`
+	ActivityType  *string  `json:"activityType"`
+	// GoalValue is in steps, meters or minutes depending on the type.
+	GoalValue  float64      `json:"goalValue"`
+	StartDate  CalendarDate `json:"startDate"`
+	EndDate    CalendarDate `json:"endDate"`
+	CreateDate CalendarDate `json:"createDate"`
+}
+
+// GoalProgress is how far along a goal is, Value is in the unit of the goal.
+type GoalProgress struct {
+	Goal    Goal
+	Value   float64
+	Percent float64
+}
+
+func newGoalProgress(g *Goal, value float64) *GoalProgress {
+	gp := GoalProgress{Goal: *g, Value: value}
+	if g.GoalValue > 0 {
+		gp.Percent = value / g.GoalValue * 100
+	}
+	return &gp
+}
+
+// Goals returns the goals with the status. start is 1 based.
+func (gs *GoalService) Goals(status GoalStatus, start, limit int) (res []Goal, err error) {
+	// GET https://connect.garmin.com/goal-service/goal/goals?status=active&start=1&limit=30
+	params := url.Values{
+		"status": {string(status)},
+		"start":  {strconv.Itoa(start)},
+		"limit":  {strconv.Itoa(limit)},
+	}
+	return res, gs.c.apiGet(&res, "/goal-service/goal/goals", params)
+}
+
+// progressChunk is the most days Progress asks the daily stats for at once,
+// Garmin rejects longer ranges.
+const progressChunk = 28
+
+// Progress adds up the steps, distance or intensity minutes from the start of
+// the goal up to today, or to its end if it has already ended. Vigorous
+// minutes count double towards intensity minute goals, the same way Garmin
+// counts them. Goals for one activity type, like running distance goals, are
+// not supported because the daily stats count every activity.
+func (gs *GoalService) Progress(g *Goal) (*GoalProgress, error) {
+	if g.ActivityType != nil && len(*g.ActivityType) > 0 {
+		return nil, fmt.Errorf("progress of %s goals for %s activities is not supported", g.GoalType, *g.ActivityType)
+	}
+	if g.StartDate.IsZero() {
+		return nil, fmt.Errorf("goal %d has no start date", g.GoalPk)
+	}
+	start := g.StartDate.In(time.UTC)
+	end := gs.c.Clock.Now()
+	if !g.EndDate.IsZero() && g.EndDate.In(time.UTC).Before(end) {
+		end = g.EndDate.In(time.UTC)
+	}
+	y, m, d := end.Date()
+	end = time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	var value float64
+	for from := start; !from.After(end); from = from.AddDate(0, 0, progressChunk) {
+		to := from.AddDate(0, 0, progressChunk-1)
+		if to.After(end) {
+			to = end
+		}
+		v, err := gs.progress(g.GoalType, from, to)
+		if err != nil {
+			return nil, err
+		}
+		value += v
+	}
+	return newGoalProgress(g, value), nil
+}
+
+// progress adds up the goal type's daily values from start to end.
+func (gs *GoalService) progress(typ GoalType, start, end time.Time) (float64, error) {
+	uss := (*UserSummaryService)(gs)
+	var value float64
+	switch typ {
+	case GoalSteps, GoalDistance:
+		steps, err := uss.DailySteps(start, end)
+		if err != nil {
+			return 0, err
+		}
+		for _, s := range steps.Values {
+			if typ == GoalSteps {
+				value += float64(s.Values.TotalSteps)
+			} else {
+				value += float64(s.Values.TotalDistance)
+			}
+		}
+	case GoalIntensityMinutes:
+		im, err := uss.DailyIntensityMinutes(start, end)
+		if err != nil {
+			return 0, err
+		}
+		for _, s := range im {
+			value += float64(s.ModerateValue + 2*s.VigorousValue)
+		}
+	default:
+		return 0, fmt.Errorf("progress of %s goals is not supported", typ)
+	}
+	return value, nil
+}
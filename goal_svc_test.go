@@ -0,0 +1,88 @@
+package garmin
+
+import (
+	"math"
+	"net/http"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestGoalProgress(t *testing.T) {
+	transport := &fakeTransport{fn: func(req *http.Request) (*http.Response, error) {
+		switch req.URL.Path {
+		case "/goal-service/goal/goals":
+			if req.URL.Query().Get("status") != "active" {
+				break
+			}
+			return jsonResponse(req, http.StatusOK, `[
+				{"goalPk": 1, "goalType": "STEPS", "goalValue": 70000, "startDate": "2024-08-12", "endDate": "2024-08-18"},
+				{"goalPk": 2, "goalType": "INTENSITY_MINUTES", "goalValue": 150, "startDate": "2024-08-12", "endDate": "2024-08-18"}
+			]`), nil
+		case "/usersummary-service/stats/daily/2024-08-12/2024-08-14":
+			return jsonResponse(req, http.StatusOK, `{"values": [
+				{"calendarDate": "2024-08-12", "values": {"totalSteps": 10000, "totalDistance": 8000}},
+				{"calendarDate": "2024-08-13", "values": {"totalSteps": 12000, "totalDistance": 9000}},
+				{"calendarDate": "2024-08-14", "values": {"totalSteps": 6000, "totalDistance": 5000}}
+			]}`), nil
+		case "/usersummary-service/stats/im/daily/2024-08-12/2024-08-14":
+			return jsonResponse(req, http.StatusOK, `[
+				{"calendarDate": "2024-08-12", "moderateValue": 20, "vigorousValue": 10},
+				{"calendarDate": "2024-08-14", "moderateValue": 0, "vigorousValue": 30}
+			]`), nil
+		}
+		return jsonResponse(req, http.StatusNotFound, `{}`), nil
+	}}
+	now := time.Date(2024, 8, 14, 18, 0, 0, 0, time.UTC)
+	api := NewAPI(NewClient(WithTransport(transport), WithClock(fakeClock(now))))
+
+	goals, err := api.Goal.Goals(GoalsActive, 1, 30)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(goals) != 2 {
+		t.Fatalf("goals: %+v", goals)
+	}
+	for i, exp := range []GoalProgress{{Value: 28000, Percent: 40}, {Value: 100, Percent: 200.0 / 3}} {
+		gp, err := api.Goal.Progress(&goals[i])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if gp.Value != exp.Value || math.Abs(gp.Percent-exp.Percent) > 1e-9 {
+			t.Errorf("%s: got %v (%v%%), want %v (%v%%)", goals[i].GoalType, gp.Value, gp.Percent, exp.Value, exp.Percent)
+		}
+	}
+}
+
+func TestGoalProgressLongRange(t *testing.T) {
+	var paths []string
+	transport := &fakeTransport{fn: func(req *http.Request) (*http.Response, error) {
+		paths = append(paths, req.URL.Path)
+		return jsonResponse(req, http.StatusOK, `{"values": [{"calendarDate": "2024-07-01", "values": {"totalSteps": 1000}}]}`), nil
+	}}
+	now := time.Date(2024, 8, 14, 18, 0, 0, 0, time.UTC)
+	api := NewAPI(NewClient(WithTransport(transport), WithClock(fakeClock(now))))
+
+	goal := Goal{GoalType: GoalSteps, GoalValue: 100000, StartDate: NewCalendarDate(2024, 7, 1)}
+	gp, err := api.Goal.Progress(&goal)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		"/usersummary-service/stats/daily/2024-07-01/2024-07-28",
+		"/usersummary-service/stats/daily/2024-07-29/2024-08-14",
+	}
+	if !slices.Equal(paths, want) {
+		t.Errorf("requests: got %v, want %v", paths, want)
+	}
+	if gp.Value != 2000 {
+		t.Errorf("value: got %v", gp.Value)
+	}
+
+	running := "running"
+	goal.ActivityType = &running
+	paths = nil
+	if _, err = api.Goal.Progress(&goal); err == nil || len(paths) > 0 {
+		t.Errorf("running goal: got %v after %d requests", err, len(paths))
+	}
+}
@@ -0,0 +1,145 @@
+package garmin
+
+import (
+	"fmt"
+	"time"
+)
+
+type HRVService service
+
+// HRVStatus compares the 7 day average to the baseline.
+type HRVStatus string
+
+const (
+	HRVStatusNone       HRVStatus = "NONE"
+	HRVStatusBalanced   HRVStatus = "BALANCED"
+	HRVStatusUnbalanced HRVStatus = "UNBALANCED"
+	HRVStatusLow        HRVStatus = "LOW"
+	HRVStatusPoor       HRVStatus = "POOR"
+)
+
+// HRVBaseline is the personal range the weekly average is compared to, in
+// milliseconds.
+type HRVBaseline struct {
+	LowUpper      int     `json:"lowUpper"`
+	BalancedLow   int     `json:"balancedLow"`
+	BalancedUpper int     `json:"balancedUpper"`
+	MarkerValue   float64 `json:"markerValue"`
+}
+
+// HRVSummary is the nightly HRV summary, values are in milliseconds.
+type HRVSummary struct {
+	CalendarDate      CalendarDate    `json:"calendarDate"`
+	WeeklyAvg         *int            `json:"weeklyAvg"`
+	LastNightAvg      *int            `json:"lastNightAvg"`
+	LastNight5MinHigh *int            `json:"lastNight5MinHigh"`
+	Baseline          *HRVBaseline    `json:"baseline"`
+	Status            HRVStatus       `json:"status"`
+	FeedbackPhrase    string          `json:"feedbackPhrase"`
+	CreateTimeStamp   GarminLocalTime `json:"createTimeStamp"`
+}
+
+// HRVReading is the HRV of one 5 minute period during sleep.
+type HRVReading struct {
+	HRVValue         int             `json:"hrvValue"`
+	ReadingTimeGMT   GarminGMTTime   `json:"readingTimeGMT"`
+	ReadingTimeLocal GarminLocalTime `json:"readingTimeLocal"`
+}
+
+type HRVData struct {
+	UserProfilePK            int64           `json:"userProfilePk"`
+	HRVSummary               HRVSummary      `json:"hrvSummary"`
+	HRVReadings              []HRVReading    `json:"hrvReadings"`
+	StartTimestampGMT        GarminGMTTime   `json:"startTimestampGMT"`
+	EndTimestampGMT          GarminGMTTime   `json:"endTimestampGMT"`
+	StartTimestampLocal      GarminLocalTime `json:"startTimestampLocal"`
+	EndTimestampLocal        GarminLocalTime `json:"endTimestampLocal"`
+	SleepStartTimestampGMT   GarminGMTTime   `json:"sleepStartTimestampGMT"`
+	SleepEndTimestampGMT     GarminGMTTime   `json:"sleepEndTimestampGMT"`
+	SleepStartTimestampLocal GarminLocalTime `json:"sleepStartTimestampLocal"`
+	SleepEndTimestampLocal   GarminLocalTime `json:"sleepEndTimestampLocal"`
+}
+
+// Daily returns the summary and the 5 minute readings of the night that ended
+// on date.
+func (hs *HRVService) Daily(date time.Time) (*HRVData, error) {
+	// GET https://connect.garmin.com/hrv-service/hrv/2024-08-16
+	var hd HRVData
+	p := fmt.Sprintf("/hrv-service/hrv/%s", date.Format(time.DateOnly))
+	return &hd, hs.c.apiGet(&hd, p, nil)
+}
+
+// Summaries returns the nightly summaries between start and end.
+func (hs *HRVService) Summaries(start, end time.Time) ([]Stat[HRVSummary], error) {
+	// GET https://connect.garmin.com/hrv-service/hrv/daily/2024-07-20/2024-08-16
+	var res struct {
+		HRVSummaries []HRVSummary `json:"hrvSummaries"`
+	}
+	if err := hs.c.apiGet(&res, datepath("/hrv-service/hrv/daily", start, end), nil); err != nil {
+		return nil, err
+	}
+	stats := make([]Stat[HRVSummary], len(res.HRVSummaries))
+	for i, s := range res.HRVSummaries {
+		stats[i] = Stat[HRVSummary]{CalendarDate: s.CalendarDate.String(), Values: s}
+	}
+	return stats, nil
+}
+
+// WeeklyHRV summarizes the nights of a week, values are in milliseconds.
+type WeeklyHRV struct {
+	// Nights is the number of nights with a last night average.
+	Nights int     `json:"nights"`
+	Avg    float64 `json:"avg"`
+	Min    int     `json:"min"`
+	Max    int     `json:"max"`
+	// WeeklyAvg and Status are Garmin's 7 day values on the last night.
+	WeeklyAvg int       `json:"weeklyAvg"`
+	Status    HRVStatus `json:"status"`
+}
+
+// Weekly returns a summary for each of the weeks ending on end, oldest first.
+// CalendarDate is the first day of the week.
+func (hs *HRVService) Weekly(end time.Time, weeks int) ([]Stat[WeeklyHRV], error) {
+	if weeks < 1 {
+		return nil, fmt.Errorf("weekly hrv needs at least one week, got %d", weeks)
+	}
+	start := end.AddDate(0, 0, 1-7*weeks)
+	nights, err := hs.Summaries(start, end)
+	if err != nil {
+		return nil, err
+	}
+	return weeklyHRV(nights, start, weeks), nil
+}
+
+func weeklyHRV(nights []Stat[HRVSummary], start time.Time, weeks int) []Stat[WeeklyHRV] {
+	res := make([]Stat[WeeklyHRV], weeks)
+	last := make([]CalendarDate, weeks)
+	first := NewCalendarDate(start.Date())
+	for i := range res {
+		res[i].CalendarDate = start.AddDate(0, 0, 7*i).Format(time.DateOnly)
+		res[i].Values.Status = HRVStatusNone
+	}
+	for _, n := range nights {
+		s := n.Values
+		i := int(s.CalendarDate.In(time.UTC).Sub(first.In(time.UTC)).Hours()/24) / 7
+		if i < 0 || i >= weeks {
+			continue
+		}
+		w := &res[i].Values
+		if s.LastNightAvg != nil {
+			v := *s.LastNightAvg
+			w.Avg = (w.Avg*float64(w.Nights) + float64(v)) / float64(w.Nights+1)
+			if w.Nights == 0 || v < w.Min {
+				w.Min = v
+			}
+			w.Max = max(w.Max, v)
+			w.Nights++
+		}
+		if s.CalendarDate.After(last[i]) && s.WeeklyAvg != nil {
+			last[i] = s.CalendarDate
+			w.WeeklyAvg = *s.WeeklyAvg
+			w.Status = s.Status
+		}
+	}
+	return res
+}
@@ -0,0 +1,73 @@
+package garmin
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestHRV(t *testing.T) {
+	requests := 0
+	transport := &fakeTransport{fn: func(req *http.Request) (*http.Response, error) {
+		requests++
+		switch req.URL.Path {
+		case "/hrv-service/hrv/2024-08-16":
+			return jsonResponse(req, http.StatusOK, `{
+				"userProfilePk": 1,
+				"hrvSummary": {
+					"calendarDate": "2024-08-16", "weeklyAvg": 48, "lastNightAvg": 51, "lastNight5MinHigh": 70,
+					"baseline": {"lowUpper": 40, "balancedLow": 44, "balancedUpper": 55, "markerValue": 0.45},
+					"status": "BALANCED", "feedbackPhrase": "HRV_BALANCED_2", "createTimeStamp": "2024-08-16T05:12:32.0"
+				},
+				"hrvReadings": [
+					{"hrvValue": 49, "readingTimeGMT": "2024-08-15T21:30:00.0", "readingTimeLocal": "2024-08-16T00:30:00.0"}
+				]
+			}`), nil
+		case "/hrv-service/hrv/daily/2024-08-03/2024-08-16":
+			return jsonResponse(req, http.StatusOK, `{"hrvSummaries": [
+				{"calendarDate": "2024-08-03", "weeklyAvg": 45, "lastNightAvg": 40, "status": "UNBALANCED"},
+				{"calendarDate": "2024-08-05", "weeklyAvg": 46, "lastNightAvg": 50, "status": "BALANCED"},
+				{"calendarDate": "2024-08-04", "weeklyAvg": 44, "lastNightAvg": null, "status": "LOW"},
+				{"calendarDate": "2024-08-16", "weeklyAvg": 48, "lastNightAvg": 51, "status": "BALANCED"}
+			]}`), nil
+		}
+		return jsonResponse(req, http.StatusNotFound, `{}`), nil
+	}}
+	api := NewAPI(NewClient(WithTransport(transport)))
+	end := time.Date(2024, 8, 16, 0, 0, 0, 0, time.UTC)
+
+	hd, err := api.HRV.Daily(end)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hd.HRVSummary.Status != HRVStatusBalanced || *hd.HRVSummary.LastNight5MinHigh != 70 || hd.HRVSummary.Baseline.BalancedUpper != 55 {
+		t.Errorf("summary: %+v", hd.HRVSummary)
+	}
+	if len(hd.HRVReadings) != 1 || time.Time(hd.HRVReadings[0].ReadingTimeGMT).Hour() != 21 {
+		t.Errorf("readings: %+v", hd.HRVReadings)
+	}
+
+	weeks, err := api.HRV.Weekly(end, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(weeks) != 2 || weeks[0].CalendarDate != "2024-08-03" || weeks[1].CalendarDate != "2024-08-10" {
+		t.Fatalf("weeks: %+v", weeks)
+	}
+	if w := weeks[0].Values; w.Nights != 2 || w.Avg != 45 || w.Min != 40 || w.Max != 50 || w.WeeklyAvg != 46 || w.Status != HRVStatusBalanced {
+		t.Errorf("first week: %+v", w)
+	}
+	if w := weeks[1].Values; w.Nights != 1 || w.Avg != 51 || w.Status != HRVStatusBalanced {
+		t.Errorf("second week: %+v", w)
+	}
+
+	sent := requests
+	for _, n := range []int{0, -1} {
+		if _, err = api.HRV.Weekly(end, n); err == nil {
+			t.Errorf("%d weeks: expected an error", n)
+		}
+	}
+	if requests != sent {
+		t.Errorf("%d requests sent for invalid weeks", requests-sent)
+	}
+}
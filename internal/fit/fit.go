@@ -0,0 +1,264 @@
+// Package fit is a minimal encoder for the Garmin FIT protocol. It only knows
+// the messages needed to upload data to Garmin Connect, and decodes what it
+// writes.
+package fit
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	headerSize      = 14
+	protocolVersion = 0x10
+	profileVersion  = 2132
+)
+
+// epoch is the FIT epoch, 1989-12-31T00:00:00Z.
+var epoch = time.Date(1989, time.December, 31, 0, 0, 0, 0, time.UTC)
+
+// Timestamp converts t to seconds since the FIT epoch.
+func Timestamp(t time.Time) uint32 { return uint32(t.Unix() - epoch.Unix()) }
+
+// BaseType is a FIT base type identifier.
+type BaseType byte
+
+const (
+	Enum    BaseType = 0x00
+	Uint8   BaseType = 0x02
+	Uint16  BaseType = 0x84
+	Uint32  BaseType = 0x86
+	Uint32z BaseType = 0x8C
+)
+
+func (bt BaseType) size() byte {
+	switch bt {
+	case Uint16:
+		return 2
+	case Uint32, Uint32z:
+		return 4
+	default:
+		return 1
+	}
+}
+
+// Invalid returns the value FIT uses to mark a field as not set.
+func (bt BaseType) Invalid() uint64 {
+	switch bt {
+	case Enum, Uint8:
+		return 0xFF
+	case Uint16:
+		return 0xFFFF
+	case Uint32:
+		return 0xFFFFFFFF
+	default:
+		return 0
+	}
+}
+
+// Field is one field of a message.
+type Field struct {
+	Num   byte
+	Type  BaseType
+	Value uint64
+}
+
+// Global message numbers.
+const (
+	MesgFileID      uint16 = 0
+	MesgDeviceInfo  uint16 = 23
+	MesgWeightScale uint16 = 30
+	MesgFileCreator uint16 = 49
+)
+
+// Encoder writes FIT messages into an in-memory file. Each message number
+// gets its own local message type, so the encoder supports up to 16 different
+// messages per file.
+type Encoder struct {
+	buf   bytes.Buffer
+	local map[uint16]byte
+	defs  map[uint16][]Field
+}
+
+func NewEncoder() *Encoder {
+	return &Encoder{local: make(map[uint16]byte), defs: make(map[uint16][]Field)}
+}
+
+// Write appends a data message, writing a definition message first if the
+// message number is new or its layout changed.
+func (e *Encoder) Write(mesg uint16, fields ...Field) {
+	local, ok := e.local[mesg]
+	if !ok {
+		local = byte(len(e.local))
+		e.local[mesg] = local
+	}
+	if !ok || !sameLayout(e.defs[mesg], fields) {
+		e.define(local, mesg, fields)
+		e.defs[mesg] = fields
+	}
+	e.buf.WriteByte(local & 0x0F)
+	for _, f := range fields {
+		switch f.Type.size() {
+		case 1:
+			e.buf.WriteByte(byte(f.Value))
+		case 2:
+			_ = binary.Write(&e.buf, binary.LittleEndian, uint16(f.Value))
+		case 4:
+			_ = binary.Write(&e.buf, binary.LittleEndian, uint32(f.Value))
+		}
+	}
+}
+
+func (e *Encoder) define(local byte, mesg uint16, fields []Field) {
+	e.buf.WriteByte(0x40 | local&0x0F)
+	e.buf.WriteByte(0) // reserved
+	e.buf.WriteByte(0) // little endian
+	_ = binary.Write(&e.buf, binary.LittleEndian, mesg)
+	e.buf.WriteByte(byte(len(fields)))
+	for _, f := range fields {
+		e.buf.Write([]byte{f.Num, f.Type.size(), byte(f.Type)})
+	}
+}
+
+func sameLayout(a, b []Field) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].Num != b[i].Num || a[i].Type != b[i].Type {
+			return false
+		}
+	}
+	return true
+}
+
+// Bytes returns the complete file including header and CRC.
+func (e *Encoder) Bytes() []byte {
+	var out bytes.Buffer
+	out.WriteByte(headerSize)
+	out.WriteByte(protocolVersion)
+	_ = binary.Write(&out, binary.LittleEndian, uint16(profileVersion))
+	_ = binary.Write(&out, binary.LittleEndian, uint32(e.buf.Len()))
+	out.WriteString(".FIT")
+	_ = binary.Write(&out, binary.LittleEndian, CRC(out.Bytes()))
+	out.Write(e.buf.Bytes())
+	_ = binary.Write(&out, binary.LittleEndian, CRC(out.Bytes()))
+	return out.Bytes()
+}
+
+var crcTable = [16]uint16{
+	0x0000, 0xCC01, 0xD801, 0x1400, 0xF001, 0x3C00, 0x2800, 0xE401,
+	0xA001, 0x6C00, 0x7800, 0xB401, 0x5000, 0x9C01, 0x8801, 0x4400,
+}
+
+// CRC computes the FIT CRC-16 of b.
+func CRC(b []byte) uint16 {
+	var crc uint16
+	for _, c := range b {
+		tmp := crcTable[crc&0xF]
+		crc = (crc >> 4) & 0x0FFF
+		crc = crc ^ tmp ^ crcTable[c&0xF]
+		tmp = crcTable[crc&0xF]
+		crc = (crc >> 4) & 0x0FFF
+		crc = crc ^ tmp ^ crcTable[(c>>4)&0xF]
+	}
+	return crc
+}
+
+// Message is a decoded data message.
+type Message struct {
+	Num    uint16
+	Fields []Field
+}
+
+// Field returns the field with the number.
+func (m *Message) Field(num byte) (Field, bool) {
+	for _, f := range m.Fields {
+		if f.Num == num {
+			return f, true
+		}
+	}
+	return Field{}, false
+}
+
+// Decode reads the data messages of a file written by Encoder, after checking
+// the header and file CRCs. Compressed timestamp headers, developer fields and
+// big endian messages are not supported.
+func Decode(b []byte) ([]Message, error) {
+	if len(b) < headerSize+2 || b[0] != headerSize || string(b[8:12]) != ".FIT" {
+		return nil, errors.New("fit: not a FIT file")
+	}
+	if CRC(b[:headerSize]) != 0 {
+		return nil, errors.New("fit: bad header crc")
+	}
+	if CRC(b) != 0 {
+		return nil, errors.New("fit: bad file crc")
+	}
+	size := int(binary.LittleEndian.Uint32(b[4:8]))
+	if size != len(b)-headerSize-2 {
+		return nil, fmt.Errorf("fit: data size %d does not match file size %d", size, len(b))
+	}
+	type definition struct {
+		num    uint16
+		fields []Field
+	}
+	var (
+		data = b[headerSize : len(b)-2]
+		defs = make(map[byte]definition)
+		msgs []Message
+	)
+	for len(data) > 0 {
+		header := data[0]
+		data = data[1:]
+		if header&0x80 != 0 {
+			return nil, errors.New("fit: compressed timestamp headers are not supported")
+		}
+		local := header & 0x0F
+		if header&0x40 != 0 {
+			if len(data) < 5 || data[1] != 0 {
+				return nil, errors.New("fit: bad definition message")
+			}
+			n := int(data[4])
+			if len(data) < 5+3*n {
+				return nil, errors.New("fit: truncated definition message")
+			}
+			def := definition{num: binary.LittleEndian.Uint16(data[2:4])}
+			for i := 0; i < n; i++ {
+				f := data[5+3*i : 8+3*i]
+				if BaseType(f[2]).size() != f[1] {
+					return nil, fmt.Errorf("fit: field %d has size %d for type %#x", f[0], f[1], f[2])
+				}
+				def.fields = append(def.fields, Field{Num: f[0], Type: BaseType(f[2])})
+			}
+			defs[local] = def
+			data = data[5+3*n:]
+			continue
+		}
+		def, ok := defs[local]
+		if !ok {
+			return nil, fmt.Errorf("fit: data message for undefined local type %d", local)
+		}
+		m := Message{Num: def.num}
+		for _, f := range def.fields {
+			size := int(f.Type.size())
+			if len(data) < size {
+				return nil, errors.New("fit: truncated data message")
+			}
+			switch size {
+			case 1:
+				f.Value = uint64(data[0])
+			case 2:
+				f.Value = uint64(binary.LittleEndian.Uint16(data))
+			case 4:
+				f.Value = uint64(binary.LittleEndian.Uint32(data))
+			}
+			data = data[size:]
+			m.Fields = append(m.Fields, f)
+		}
+		msgs = append(msgs, m)
+	}
+	return msgs, nil
+}
@@ -0,0 +1,55 @@
+package fit
+
+import (
+	"encoding/binary"
+	"testing"
+	"time"
+)
+
+func TestEncoder(t *testing.T) {
+	e := NewEncoder()
+	e.Write(MesgFileID, Field{Num: 0, Type: Enum, Value: 9})
+	e.Write(MesgWeightScale,
+		Field{Num: 253, Type: Uint32, Value: uint64(Timestamp(time.Unix(631065600+60, 0)))},
+		Field{Num: 0, Type: Uint16, Value: 8000},
+	)
+	b := e.Bytes()
+	if string(b[8:12]) != ".FIT" {
+		t.Fatalf("missing .FIT signature: %q", b[8:12])
+	}
+	if size := binary.LittleEndian.Uint32(b[4:8]); int(size) != len(b)-headerSize-2 {
+		t.Errorf("data size: got %d, want %d", size, len(b)-headerSize-2)
+	}
+	// The CRC of a file including its trailing CRC is zero.
+	if crc := CRC(b[:headerSize]); crc != 0 {
+		t.Errorf("header crc: got %#x", crc)
+	}
+	if crc := CRC(b); crc != 0 {
+		t.Errorf("file crc: got %#x", crc)
+	}
+	if ts := binary.LittleEndian.Uint32(b[len(b)-8:]); ts != 60 {
+		t.Errorf("timestamp: got %d", ts)
+	}
+}
+
+func TestDecode(t *testing.T) {
+	e := NewEncoder()
+	e.Write(MesgFileID, Field{Num: 0, Type: Enum, Value: 9}, Field{Num: 3, Type: Uint32z, Value: 1})
+	e.Write(MesgWeightScale, Field{Num: 0, Type: Uint16, Value: 8000})
+	e.Write(MesgWeightScale, Field{Num: 0, Type: Uint16, Value: 8100})
+	b := e.Bytes()
+	msgs, err := Decode(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(msgs) != 3 || msgs[0].Num != MesgFileID || msgs[2].Num != MesgWeightScale {
+		t.Fatalf("messages: %+v", msgs)
+	}
+	if f, ok := msgs[2].Field(0); !ok || f.Value != 8100 || f.Type != Uint16 {
+		t.Errorf("weight: %+v", f)
+	}
+	b[len(b)-3]++
+	if _, err = Decode(b); err == nil {
+		t.Error("corrupted file decoded without an error")
+	}
+}
@@ -0,0 +1,435 @@
+package rt
+
+import (
+	"bytes"
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Forever is a TTL for responses that never change.
+const Forever time.Duration = 1<<63 - 1
+
+// Cache stores cached responses by key.
+type Cache interface {
+	Get(key string) ([]byte, bool)
+	Set(key string, value []byte)
+	Delete(key string)
+}
+
+// CacheRule decides how long responses for matching endpoints are cached.
+type CacheRule struct {
+	// Pattern is matched with path.Match against the endpoint template of the
+	// request, see Templates.Endpoint, like /activity-service/activity/{id}/*.
+	Pattern string
+	// TTL returns how long the response to the request stays fresh, zero
+	// means it is not cached.
+	TTL func(req *http.Request, now time.Time) time.Duration
+}
+
+// FixedTTL caches every response for ttl.
+func FixedTTL(ttl time.Duration) func(*http.Request, time.Time) time.Duration {
+	return func(*http.Request, time.Time) time.Duration { return ttl }
+}
+
+// ForeverBefore caches responses Forever when the latest date in the path or
+// query is more than days before today, and for ttl otherwise. Data for a day
+// keeps changing for a while after the day is over, as devices sync.
+func ForeverBefore(days int, ttl time.Duration) func(*http.Request, time.Time) time.Duration {
+	return func(req *http.Request, now time.Time) time.Duration {
+		latest, ok := latestDate(req)
+		if !ok {
+			return ttl
+		}
+		y, m, d := now.Date()
+		cutoff := time.Date(y, m, d-days, 0, 0, 0, 0, time.UTC)
+		if latest.Before(cutoff) {
+			return Forever
+		}
+		return ttl
+	}
+}
+
+func latestDate(req *http.Request) (latest time.Time, ok bool) {
+	check := func(s string) {
+		if len(s) < len(time.DateOnly) {
+			return
+		}
+		if t, err := time.Parse(time.DateOnly, s[:len(time.DateOnly)]); err == nil && (!ok || t.After(latest)) {
+			latest, ok = t, true
+		}
+	}
+	for _, p := range strings.Split(req.URL.Path, "/") {
+		check(p)
+	}
+	for _, vs := range req.URL.Query() {
+		for _, v := range vs {
+			check(v)
+		}
+	}
+	return latest, ok
+}
+
+// Caching is a RoundTripper that caches GET responses for endpoints that
+// match one of its Rules. Stale responses with an ETag or Last-Modified
+// header are revalidated instead of fetched again.
+//
+// Entries are keyed by the scope set with SetScope and the URL, so that
+// clients of different accounts can share a Cache without seeing each other's
+// responses. Only the headers in cachedHeaders are stored.
+type Caching struct {
+	http.RoundTripper
+	Cache Cache
+	Rules []CacheRule
+	// Now defaults to time.Now.
+	Now func() time.Time
+	// Templates names the endpoints that Rules are matched against,
+	// PathTemplate is used when nil.
+	Templates *Templates
+
+	mu    sync.RWMutex
+	scope string
+}
+
+// cachedHeaders are the response headers kept in the cache. Everything else,
+// Set-Cookie above all, is dropped.
+var cachedHeaders = []string{"Content-Type", "ETag", "Last-Modified", "Cache-Control"}
+
+type cacheEntry struct {
+	Expires    int64       `json:"expires"` // unix ms, zero for never
+	StatusCode int         `json:"status"`
+	Header     http.Header `json:"header"`
+	Body       []byte      `json:"body"`
+}
+
+func (c *Caching) Wrap(rt http.RoundTripper) RoundTripper {
+	c.RoundTripper = rt
+	return c
+}
+
+func (c *Caching) Unwrap() http.RoundTripper { return c.RoundTripper }
+
+// SetScope sets the account that following requests are made for. Responses
+// cached in one scope are not seen in another.
+func (c *Caching) SetScope(scope string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.scope = scope
+}
+
+func (c *Caching) key(req *http.Request) string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.scope + " " + req.URL.String()
+}
+
+func (c *Caching) now() time.Time {
+	if c.Now == nil {
+		return time.Now()
+	}
+	return c.Now()
+}
+
+func (c *Caching) ttl(req *http.Request, now time.Time) time.Duration {
+	endpoint := c.Templates.Endpoint(req)
+	for _, r := range c.Rules {
+		if ok, _ := path.Match(r.Pattern, endpoint); ok {
+			return r.TTL(req, now)
+		}
+	}
+	return 0
+}
+
+func (c *Caching) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet || len(req.Header.Get("Range")) > 0 {
+		return c.RoundTripper.RoundTrip(req)
+	}
+	now := c.now()
+	ttl := c.ttl(req, now)
+	if ttl <= 0 {
+		return c.RoundTripper.RoundTrip(req)
+	}
+	key := c.key(req)
+	var entry *cacheEntry
+	if b, ok := c.Cache.Get(key); ok {
+		entry = new(cacheEntry)
+		if err := json.Unmarshal(b, entry); err != nil {
+			c.Cache.Delete(key)
+			entry = nil
+		}
+	}
+	if entry != nil && (entry.Expires == 0 || now.Before(time.UnixMilli(entry.Expires))) {
+		return entry.response(req), nil
+	}
+
+	out := req
+	if entry != nil {
+		etag, modified := entry.Header.Get("ETag"), entry.Header.Get("Last-Modified")
+		if len(etag) > 0 || len(modified) > 0 {
+			out = req.Clone(req.Context())
+			if len(etag) > 0 {
+				out.Header.Set("If-None-Match", etag)
+			}
+			if len(modified) > 0 {
+				out.Header.Set("If-Modified-Since", modified)
+			}
+		}
+	}
+	res, err := c.RoundTripper.RoundTrip(out)
+	if err != nil {
+		return res, err
+	}
+	switch {
+	case res.StatusCode == http.StatusNotModified && entry != nil:
+		_, _ = io.Copy(io.Discard, res.Body)
+		res.Body.Close()
+		entry.Expires = expires(now, ttl)
+		c.store(key, entry)
+		return entry.response(req), nil
+	case res.StatusCode != http.StatusOK:
+		return res, nil
+	}
+	body, err := io.ReadAll(res.Body)
+	if err = errors.Join(err, res.Body.Close()); err != nil {
+		return nil, err
+	}
+	res.Body = io.NopCloser(bytes.NewReader(body))
+	c.store(key, &cacheEntry{
+		Expires:    expires(now, ttl),
+		StatusCode: res.StatusCode,
+		Header:     cacheableHeader(res.Header),
+		Body:       body,
+	})
+	return res, nil
+}
+
+func expires(now time.Time, ttl time.Duration) int64 {
+	if ttl == Forever {
+		return 0
+	}
+	return now.Add(ttl).UnixMilli()
+}
+
+func cacheableHeader(h http.Header) http.Header {
+	out := make(http.Header)
+	for _, k := range cachedHeaders {
+		for _, v := range h.Values(k) {
+			out.Add(k, v)
+		}
+	}
+	return out
+}
+
+func (c *Caching) store(key string, entry *cacheEntry) {
+	if b, err := json.Marshal(entry); err == nil {
+		c.Cache.Set(key, b)
+	}
+}
+
+func (e *cacheEntry) response(req *http.Request) *http.Response {
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", e.StatusCode, http.StatusText(e.StatusCode)),
+		StatusCode:    e.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        e.Header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(e.Body)),
+		ContentLength: int64(len(e.Body)),
+		Request:       req,
+	}
+}
+
+// DefaultCacheSize is the size in bytes that caches are kept under when none
+// is given.
+const DefaultCacheSize = 64 << 20
+
+func cacheSize(maxBytes int64) int64 {
+	if maxBytes <= 0 {
+		return DefaultCacheSize
+	}
+	return maxBytes
+}
+
+// MemoryCache is a Cache that keeps responses in memory. Once they take more
+// than MaxBytes, the least recently used are dropped, whatever their TTL.
+type MemoryCache struct {
+	mu       sync.Mutex
+	maxBytes int64
+	size     int64
+	// lru holds *memoryEntry, the most recently used at the front.
+	lru     *list.List
+	entries map[string]*list.Element
+}
+
+type memoryEntry struct {
+	key   string
+	value []byte
+}
+
+// NewMemoryCache returns a MemoryCache for maxBytes of responses, or
+// DefaultCacheSize when maxBytes is zero.
+func NewMemoryCache(maxBytes int64) *MemoryCache {
+	return &MemoryCache{
+		maxBytes: cacheSize(maxBytes),
+		lru:      list.New(),
+		entries:  make(map[string]*list.Element),
+	}
+}
+
+func (mc *MemoryCache) Get(key string) ([]byte, bool) {
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+	el, ok := mc.entries[key]
+	if !ok {
+		return nil, false
+	}
+	mc.lru.MoveToFront(el)
+	return el.Value.(*memoryEntry).value, true
+}
+
+func (mc *MemoryCache) Set(key string, value []byte) {
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+	mc.remove(key)
+	if int64(len(value)) > mc.maxBytes {
+		return
+	}
+	mc.entries[key] = mc.lru.PushFront(&memoryEntry{key: key, value: value})
+	mc.size += int64(len(value))
+	for mc.size > mc.maxBytes {
+		mc.remove(mc.lru.Back().Value.(*memoryEntry).key)
+	}
+}
+
+func (mc *MemoryCache) Delete(key string) {
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+	mc.remove(key)
+}
+
+func (mc *MemoryCache) remove(key string) {
+	if el, ok := mc.entries[key]; ok {
+		mc.size -= int64(len(el.Value.(*memoryEntry).value))
+		mc.lru.Remove(el)
+		delete(mc.entries, key)
+	}
+}
+
+// DirCache is a Cache that keeps one file per response in Dir. Errors are
+// treated as cache misses. Once the files take more than MaxBytes, the least
+// recently used are removed.
+type DirCache struct {
+	Dir      string
+	MaxBytes int64
+
+	mu sync.Mutex
+	// size is the size of the files in Dir, once counted.
+	size    int64
+	counted bool
+}
+
+// NewDirCache returns a DirCache for maxBytes of responses, or
+// DefaultCacheSize when maxBytes is zero.
+func NewDirCache(dir string, maxBytes int64) (*DirCache, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, err
+	}
+	return &DirCache{Dir: dir, MaxBytes: cacheSize(maxBytes)}, nil
+}
+
+func (dc *DirCache) file(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(dc.Dir, hex.EncodeToString(sum[:]))
+}
+
+func (dc *DirCache) Get(key string) ([]byte, bool) {
+	name := dc.file(key)
+	b, err := os.ReadFile(name)
+	if err != nil {
+		return nil, false
+	}
+	// The modification time tells prune which files were used last.
+	now := time.Now()
+	_ = os.Chtimes(name, now, now)
+	return b, true
+}
+
+func (dc *DirCache) Set(key string, value []byte) {
+	if int64(len(value)) > cacheSize(dc.MaxBytes) {
+		dc.Delete(key)
+		return
+	}
+	f, err := os.CreateTemp(dc.Dir, ".tmp-*")
+	if err != nil {
+		return
+	}
+	_, err = f.Write(value)
+	if err = errors.Join(err, f.Close()); err == nil {
+		err = os.Rename(f.Name(), dc.file(key))
+	}
+	if err != nil {
+		os.Remove(f.Name())
+		return
+	}
+	dc.mu.Lock()
+	defer dc.mu.Unlock()
+	// A replaced file is counted twice until the next prune recounts.
+	dc.size += int64(len(value))
+	if !dc.counted || dc.size > cacheSize(dc.MaxBytes) {
+		dc.prune()
+	}
+}
+
+func (dc *DirCache) Delete(key string) { _ = os.Remove(dc.file(key)) }
+
+// prune removes the least recently used files until the rest fit in
+// MaxBytes, and counts what is left.
+func (dc *DirCache) prune() {
+	entries, err := os.ReadDir(dc.Dir)
+	if err != nil {
+		return
+	}
+	type file struct {
+		name string
+		size int64
+		used time.Time
+	}
+	var (
+		files []file
+		total int64
+	)
+	for _, e := range entries {
+		if e.IsDir() || strings.HasPrefix(e.Name(), ".") {
+			continue
+		}
+		info, err := e.Info()
+		if err != nil {
+			continue
+		}
+		files = append(files, file{name: e.Name(), size: info.Size(), used: info.ModTime()})
+		total += info.Size()
+	}
+	slices.SortFunc(files, func(a, b file) int { return a.used.Compare(b.used) })
+	for _, f := range files {
+		if total <= cacheSize(dc.MaxBytes) {
+			break
+		}
+		if err := os.Remove(filepath.Join(dc.Dir, f.name)); err == nil {
+			total -= f.size
+		}
+	}
+	dc.size, dc.counted = total, true
+}
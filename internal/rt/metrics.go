@@ -0,0 +1,240 @@
+package rt
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RequestEvent describes one request for an Observer. The fields after Start
+// are set when the request ends.
+type RequestEvent struct {
+	Method string
+	Host   string
+	// Endpoint is the path with ids and dates replaced by placeholders, like
+	// /activity-service/activity/{id}.
+	Endpoint string
+	Start    time.Time
+
+	Status        int
+	Latency       time.Duration
+	RequestBytes  int64
+	ResponseBytes int64
+	Err           error
+}
+
+// RefreshEvent describes an exchange of the OAuth1 token for a new access
+// token.
+type RefreshEvent struct {
+	Latency time.Duration
+	Err     error
+}
+
+// Observer is told about every request made through Metrics. Methods are
+// called concurrently and should return quickly.
+type Observer interface {
+	RequestStart(*RequestEvent)
+	// RequestEnd is called once the response body is closed or read to the
+	// end, or right away when there is no response.
+	RequestEnd(*RequestEvent)
+	TokenRefresh(*RefreshEvent)
+}
+
+// Metrics is a RoundTripper that reports requests to an Observer.
+type Metrics struct {
+	http.RoundTripper
+	Observer Observer
+	// Templates names the endpoints of requests, PathTemplate is used when
+	// nil.
+	Templates *Templates
+	// Now defaults to time.Now.
+	Now func() time.Time
+}
+
+func (m *Metrics) Wrap(rt http.RoundTripper) RoundTripper {
+	m.RoundTripper = rt
+	return m
+}
+
+func (m *Metrics) Unwrap() http.RoundTripper { return m.RoundTripper }
+
+func (m *Metrics) now() time.Time {
+	if m.Now == nil {
+		return time.Now()
+	}
+	return m.Now()
+}
+
+func (m *Metrics) RoundTrip(req *http.Request) (*http.Response, error) {
+	ev := RequestEvent{
+		Method:   req.Method,
+		Host:     req.URL.Host,
+		Endpoint: m.Templates.Endpoint(req),
+		Start:    m.now(),
+	}
+	var reqBody *countingReader
+	if req.Body != nil && req.Body != http.NoBody {
+		reqBody = &countingReader{ReadCloser: req.Body}
+		req.Body = reqBody
+	}
+	m.Observer.RequestStart(&ev)
+
+	res, err := m.RoundTripper.RoundTrip(req)
+	ev.Latency = m.now().Sub(ev.Start)
+	end := func(n int64) {
+		ev.ResponseBytes = n
+		if reqBody != nil {
+			ev.RequestBytes = reqBody.n
+		}
+		m.Observer.RequestEnd(&ev)
+	}
+	if err != nil {
+		ev.Err = err
+		end(0)
+		return res, err
+	}
+	ev.Status = res.StatusCode
+	if res.Body == nil {
+		end(0)
+		return res, nil
+	}
+	res.Body = &countingReader{ReadCloser: res.Body, done: end}
+	return res, nil
+}
+
+type endpointKey struct{}
+
+// WithEndpoint sets the endpoint template reported for requests made with the
+// context, instead of the one guessed from the path.
+func WithEndpoint(ctx context.Context, endpoint string) context.Context {
+	return context.WithValue(ctx, endpointKey{}, endpoint)
+}
+
+var endpointSegments = []struct {
+	re          *regexp.Regexp
+	placeholder string
+}{
+	{regexp.MustCompile(`^\d{4}-\d{2}-\d{2}$`), "{date}"},
+	{regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`), "{uuid}"},
+	{regexp.MustCompile(`^\d+$`), "{id}"},
+}
+
+// Endpoint returns the endpoint template for the request, set with
+// WithEndpoint or else PathTemplate.
+func Endpoint(req *http.Request) string {
+	return (*Templates)(nil).Endpoint(req)
+}
+
+// Templates matches paths against known endpoint templates, so that segments
+// PathTemplate can't recognise, like display names, are replaced with the
+// template's placeholders. A nil *Templates only uses PathTemplate.
+type Templates struct {
+	bySegments map[int][][]string
+}
+
+// NewTemplates returns Templates for paths with placeholders in braces, like
+// /course-service/course/owner/{displayName}.
+func NewTemplates(templates ...string) *Templates {
+	t := Templates{bySegments: make(map[int][][]string)}
+	for _, tmpl := range templates {
+		parts := strings.Split(tmpl, "/")
+		t.bySegments[len(parts)] = append(t.bySegments[len(parts)], parts)
+	}
+	return &t
+}
+
+// Endpoint returns the endpoint template for the request, set with
+// WithEndpoint or else Match.
+func (t *Templates) Endpoint(req *http.Request) string {
+	if e, ok := req.Context().Value(endpointKey{}).(string); ok {
+		return e
+	}
+	return t.Match(req.URL.EscapedPath())
+}
+
+// Match returns the template that matches path with the most literal
+// segments, or PathTemplate(path) when none does.
+func (t *Templates) Match(path string) string {
+	if t == nil {
+		return PathTemplate(path)
+	}
+	parts := strings.Split(path, "/")
+	var (
+		best     []string
+		literals = -1
+	)
+	for _, tmpl := range t.bySegments[len(parts)] {
+		if n, ok := matchSegments(tmpl, parts); ok && n > literals {
+			best, literals = tmpl, n
+		}
+	}
+	if best == nil {
+		return PathTemplate(path)
+	}
+	return strings.Join(best, "/")
+}
+
+// matchSegments reports whether parts match tmpl, and how many of the
+// segments were literal.
+func matchSegments(tmpl, parts []string) (literals int, ok bool) {
+	for i, seg := range tmpl {
+		if strings.HasPrefix(seg, "{") && strings.HasSuffix(seg, "}") {
+			if len(parts[i]) == 0 {
+				return 0, false
+			}
+			continue
+		}
+		if seg != parts[i] {
+			return 0, false
+		}
+		literals++
+	}
+	return literals, true
+}
+
+// PathTemplate replaces dates, uuids and numbers in the path with {date},
+// {uuid} and {id}.
+func PathTemplate(path string) string {
+	parts := strings.Split(path, "/")
+	for i, p := range parts {
+		for _, s := range endpointSegments {
+			if s.re.MatchString(p) {
+				parts[i] = s.placeholder
+				break
+			}
+		}
+	}
+	return strings.Join(parts, "/")
+}
+
+type countingReader struct {
+	io.ReadCloser
+	n    int64
+	done func(int64)
+	once sync.Once
+}
+
+func (cr *countingReader) Read(p []byte) (int, error) {
+	n, err := cr.ReadCloser.Read(p)
+	cr.n += int64(n)
+	if err == io.EOF {
+		cr.finish()
+	}
+	return n, err
+}
+
+func (cr *countingReader) Close() error {
+	err := cr.ReadCloser.Close()
+	cr.finish()
+	return err
+}
+
+func (cr *countingReader) finish() {
+	if cr.done != nil {
+		cr.once.Do(func() { cr.done(cr.n) })
+	}
+}
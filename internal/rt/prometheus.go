@@ -0,0 +1,155 @@
+package rt
+
+import (
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// DefaultBuckets are the latency histogram buckets in seconds.
+var DefaultBuckets = []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// Prometheus is an Observer that keeps counters and latency histograms and
+// serves them in the Prometheus text format.
+type Prometheus struct {
+	Namespace string
+	Buckets   []float64
+
+	mu        sync.Mutex
+	inFlight  int64
+	requests  map[string]float64 // method, endpoint, status
+	reqBytes  map[string]float64 // method, endpoint
+	resBytes  map[string]float64 // method, endpoint
+	refreshes map[string]float64 // result
+	latency   map[string]*histogram
+}
+
+type histogram struct {
+	counts []uint64
+	count  uint64
+	sum    float64
+}
+
+func NewPrometheus(namespace string) *Prometheus {
+	return &Prometheus{
+		Namespace: namespace,
+		Buckets:   DefaultBuckets,
+		requests:  make(map[string]float64),
+		reqBytes:  make(map[string]float64),
+		resBytes:  make(map[string]float64),
+		refreshes: make(map[string]float64),
+		latency:   make(map[string]*histogram),
+	}
+}
+
+func labels(kv ...string) string {
+	var b strings.Builder
+	for i := 0; i < len(kv); i += 2 {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		fmt.Fprintf(&b, "%s=%q", kv[i], kv[i+1])
+	}
+	return b.String()
+}
+
+func (p *Prometheus) RequestStart(*RequestEvent) {
+	p.mu.Lock()
+	p.inFlight++
+	p.mu.Unlock()
+}
+
+func (p *Prometheus) RequestEnd(ev *RequestEvent) {
+	status := strconv.Itoa(ev.Status)
+	if ev.Err != nil {
+		status = "error"
+	}
+	l := labels("method", ev.Method, "endpoint", ev.Endpoint)
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.inFlight--
+	p.requests[labels("method", ev.Method, "endpoint", ev.Endpoint, "status", status)]++
+	p.reqBytes[l] += float64(ev.RequestBytes)
+	p.resBytes[l] += float64(ev.ResponseBytes)
+	h, ok := p.latency[l]
+	if !ok {
+		h = &histogram{counts: make([]uint64, len(p.Buckets))}
+		p.latency[l] = h
+	}
+	secs := ev.Latency.Seconds()
+	for i, le := range p.Buckets {
+		if secs <= le {
+			h.counts[i]++
+		}
+	}
+	h.count++
+	h.sum += secs
+}
+
+func (p *Prometheus) TokenRefresh(ev *RefreshEvent) {
+	result := "ok"
+	if ev.Err != nil {
+		result = "error"
+	}
+	p.mu.Lock()
+	p.refreshes[labels("result", result)]++
+	p.mu.Unlock()
+}
+
+// ServeHTTP serves WriteText.
+func (p *Prometheus) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	_ = p.WriteText(w)
+}
+
+// WriteText writes all metrics in the Prometheus text exposition format.
+func (p *Prometheus) WriteText(w io.Writer) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	var b strings.Builder
+	name := func(n string) string {
+		if len(p.Namespace) == 0 {
+			return n
+		}
+		return p.Namespace + "_" + n
+	}
+	header := func(n, typ, help string) {
+		fmt.Fprintf(&b, "# HELP %s %s\n# TYPE %s %s\n", n, help, n, typ)
+	}
+	counter := func(n, help string, m map[string]float64) {
+		n = name(n)
+		header(n, "counter", help)
+		for _, l := range slices.Sorted(maps.Keys(m)) {
+			fmt.Fprintf(&b, "%s{%s} %s\n", n, l, formatFloat(m[l]))
+		}
+	}
+	counter("requests_total", "Requests by endpoint and status code.", p.requests)
+	counter("request_bytes_total", "Bytes sent in request bodies.", p.reqBytes)
+	counter("response_bytes_total", "Bytes received in response bodies.", p.resBytes)
+	counter("token_refreshes_total", "Access token refreshes by result.", p.refreshes)
+
+	n := name("requests_in_flight")
+	header(n, "gauge", "Requests waiting for a response.")
+	fmt.Fprintf(&b, "%s %d\n", n, p.inFlight)
+
+	n = name("request_duration_seconds")
+	header(n, "histogram", "Time until the response headers were received.")
+	for _, l := range slices.Sorted(maps.Keys(p.latency)) {
+		h := p.latency[l]
+		for i, le := range p.Buckets {
+			fmt.Fprintf(&b, "%s_bucket{%s,le=%q} %d\n", n, l, formatFloat(le), h.counts[i])
+		}
+		fmt.Fprintf(&b, "%s_bucket{%s,le=\"+Inf\"} %d\n", n, l, h.count)
+		fmt.Fprintf(&b, "%s_sum{%s} %s\n", n, l, formatFloat(h.sum))
+		fmt.Fprintf(&b, "%s_count{%s} %d\n", n, l, h.count)
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func formatFloat(f float64) string { return strconv.FormatFloat(f, 'g', -1, 64) }
@@ -0,0 +1,93 @@
+package rt
+
+import (
+	"net/http"
+	"sync"
+	"time"
+)
+
+// RateLimiter is a token bucket RoundTripper. It lets Burst requests through
+// at once and then one request every Interval, blocking until the request's
+// context is done.
+type RateLimiter struct {
+	http.RoundTripper
+	Interval time.Duration
+	Burst    int
+	// Now returns the current time, time.Now is used when nil.
+	Now func() time.Time
+	// After waits for a duration of Now to pass, time.After is used when
+	// nil.
+	After func(time.Duration) <-chan time.Time
+
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time
+}
+
+func NewRateLimiter(interval time.Duration, burst int) *RateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+	return &RateLimiter{Interval: interval, Burst: burst, tokens: float64(burst)}
+}
+
+func (rl *RateLimiter) Wrap(rt http.RoundTripper) RoundTripper {
+	rl.RoundTripper = rt
+	return rl
+}
+
+func (rl *RateLimiter) Unwrap() http.RoundTripper { return rl.RoundTripper }
+
+func (rl *RateLimiter) RoundTrip(req *http.Request) (*http.Response, error) {
+	if wait := rl.reserve(rl.now()); wait > 0 {
+		select {
+		case <-req.Context().Done():
+			rl.release()
+			return nil, req.Context().Err()
+		case <-rl.after(wait):
+		}
+	}
+	return rl.RoundTripper.RoundTrip(req)
+}
+
+func (rl *RateLimiter) after(d time.Duration) <-chan time.Time {
+	if rl.After == nil {
+		return time.After(d)
+	}
+	return rl.After(d)
+}
+
+func (rl *RateLimiter) now() time.Time {
+	if rl.Now == nil {
+		return time.Now()
+	}
+	return rl.Now()
+}
+
+// reserve takes a token and returns how long the caller has to wait for it.
+func (rl *RateLimiter) reserve(now time.Time) time.Duration {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+	if rl.Interval <= 0 {
+		return 0
+	}
+	if !rl.last.IsZero() {
+		rl.tokens += float64(now.Sub(rl.last)) / float64(rl.Interval)
+		if rl.tokens > float64(rl.Burst) {
+			rl.tokens = float64(rl.Burst)
+		}
+	}
+	rl.last = now
+	rl.tokens--
+	if rl.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-rl.tokens * float64(rl.Interval))
+}
+
+// release gives back a token reserved for a request that was not sent.
+func (rl *RateLimiter) release() {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+	rl.tokens = min(rl.tokens+1, float64(rl.Burst))
+}
@@ -0,0 +1,43 @@
+package rt
+
+import (
+	"net/http"
+	"net/url"
+	"regexp"
+)
+
+const Redacted = "REDACTED"
+
+// sensitive are names of form fields, query parameters and JSON keys whose
+// values never end up in logs.
+const sensitive = `password|mfa-code|_csrf|ticket|access_token|refresh_token|oauth_token|oauth_token_secret|oauth_verifier|token|token_secret|jwt_\w+`
+
+var (
+	redactFormRe = regexp.MustCompile(`(^|[&?])(` + sensitive + `)=[^&"'\s<]*`)
+	redactJSONRe = regexp.MustCompile(`("(?:` + sensitive + `)"\s*:\s*)"(?:[^"\\]|\\.)*"`)
+)
+
+// RedactHeader returns the header value as it can be logged.
+func RedactHeader(name, value string) string {
+	switch http.CanonicalHeaderKey(name) {
+	case "Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie":
+		return Redacted
+	}
+	return value
+}
+
+// RedactURL replaces sensitive query parameters.
+func RedactURL(u *url.URL) string {
+	if len(u.RawQuery) == 0 {
+		return u.String()
+	}
+	c := *u
+	c.RawQuery = redactFormRe.ReplaceAllString(c.RawQuery, "$1$2="+Redacted)
+	return c.String()
+}
+
+// RedactBody replaces passwords and tokens in url encoded forms and JSON.
+func RedactBody(body string) string {
+	body = redactFormRe.ReplaceAllString(body, "$1$2="+Redacted)
+	return redactJSONRe.ReplaceAllString(body, `$1"`+Redacted+`"`)
+}
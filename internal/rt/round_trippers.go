@@ -6,6 +6,7 @@ import (
 	"log/slog"
 	"net/http"
 	"strings"
+	"sync/atomic"
 )
 
 type RoundTripper interface {
@@ -26,11 +27,13 @@ func RoundTrippers(base http.RoundTripper, parents ...RoundTripper) http.RoundTr
 }
 
 // Debugger is a RoundTripper that prints debug info about http requests and
-// responses.
+// responses. Passwords, tokens and cookies are redacted.
 type Debugger struct {
 	http.RoundTripper
 	SkipBody bool
-	count    int
+	// Logger defaults to slog.Default.
+	Logger *slog.Logger
+	count  atomic.Int64
 }
 
 func (d *Debugger) Wrap(rt http.RoundTripper) RoundTripper {
@@ -66,25 +69,29 @@ func (d *Debugger) RoundTrip(req *http.Request) (*http.Response, error) {
 		}
 		res.Body = io.NopCloser(bytes.NewReader(resbody.Bytes()))
 	}
+	log := d.Logger
+	if log == nil {
+		log = slog.Default()
+	}
+	ctx := req.Context()
 	r := res.Request
-	id := slog.Int("id", d.count)
-	slog.Debug("START")
-	slog.Info("Send", id, slog.String("method", r.Method), slog.String("url", r.URL.String()))
+	id := slog.Int64("id", d.count.Add(1)-1)
+	log.DebugContext(ctx, "START")
+	log.InfoContext(ctx, "Send", id, slog.String("method", r.Method), slog.String("url", RedactURL(r.URL)))
 	for k, v := range r.Header {
-		slog.Debug("request header", slog.String(k, strings.Join(v, ", ")))
+		log.DebugContext(ctx, "request header", slog.String(k, RedactHeader(k, strings.Join(v, ", "))))
 	}
 	if !d.SkipBody && reqbody.Len() > 0 {
-		slog.Debug("request body", slog.String("body", reqbody.String()))
+		log.DebugContext(ctx, "request body", slog.String("body", RedactBody(reqbody.String())))
 	}
-	slog.Info("Receive", id, slog.String("status", res.Status))
+	log.InfoContext(ctx, "Receive", id, slog.String("status", res.Status))
 	for k, v := range res.Header {
-		slog.Debug("response header", slog.String(k, strings.Join(v, ", ")))
+		log.DebugContext(ctx, "response header", slog.String(k, RedactHeader(k, strings.Join(v, ", "))))
 	}
 	if !d.SkipBody && resbody.Len() > 0 {
-		slog.Debug("response body", slog.String("body", resbody.String()))
+		log.DebugContext(ctx, "response body", slog.String("body", RedactBody(resbody.String())))
 	}
-	slog.Debug("END")
-	d.count += 1
+	log.DebugContext(ctx, "END")
 	return res, nil
 }
 
@@ -154,3 +161,24 @@ func removeHttpTransport(transport http.RoundTripper) http.RoundTripper {
 		// }
 	}
 }
+
+// Remove takes target out of the chain of RoundTrippers starting at chain and
+// returns the new start of the chain. The chain is returned unchanged if
+// target is not part of it.
+func Remove(chain http.RoundTripper, target RoundTripper) http.RoundTripper {
+	if chain == http.RoundTripper(target) {
+		return target.Unwrap()
+	}
+	for cur := chain; ; {
+		parent, ok := cur.(RoundTripper)
+		if !ok {
+			return chain
+		}
+		next := parent.Unwrap()
+		if next == http.RoundTripper(target) {
+			parent.Wrap(target.Unwrap())
+			return chain
+		}
+		cur = next
+	}
+}
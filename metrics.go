@@ -0,0 +1,33 @@
+package garmin
+
+import "github.com/jylitalo/go-garmin/internal/rt"
+
+type (
+	// Observer is told about every request the client makes and every access
+	// token refresh, see WithObserver.
+	Observer     = rt.Observer
+	RequestEvent = rt.RequestEvent
+	RefreshEvent = rt.RefreshEvent
+	// PrometheusObserver is an Observer that is also an http.Handler serving
+	// the metrics in the Prometheus text format.
+	PrometheusObserver = rt.Prometheus
+)
+
+// NewPrometheusObserver returns an Observer with metrics named garmin_*.
+//
+//	metrics := garmin.NewPrometheusObserver()
+//	client := garmin.NewClient(garmin.WithObserver(metrics))
+//	http.Handle("/metrics", metrics)
+func NewPrometheusObserver() *PrometheusObserver { return rt.NewPrometheus("garmin") }
+
+// WithObserver reports requests and token refreshes to the observer. It
+// measures requests after all transports added with WithTransport.
+func WithObserver(o Observer) ClientOpt {
+	return func(co *clientOpts) { co.Observer = o }
+}
+
+func (c *Client) observeRefresh(ev *RefreshEvent) {
+	if c.observer != nil {
+		c.observer.TokenRefresh(ev)
+	}
+}
@@ -0,0 +1,139 @@
+package garmin
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestMetrics(t *testing.T) {
+	prev := getOAuthConsumer
+	getOAuthConsumer = func() (*oAuthConsumer, error) { return &oAuthConsumer{Key: "key", Secret: "secret"}, nil }
+	defer func() { getOAuthConsumer = prev }()
+
+	transport := &fakeTransport{fn: func(req *http.Request) (*http.Response, error) {
+		switch req.URL.Path {
+		case "/oauth-service/oauth/exchange/user/2.0":
+			return jsonResponse(req, http.StatusOK, `{"access_token": "fresh", "token_type": "Bearer", "expires_in": 3600}`), nil
+		case "/activity-service/activity/123":
+			return jsonResponse(req, http.StatusOK, `{"activityId": 123}`), nil
+		case "/metrics-service/metrics/racepredictions/latest/jdoe":
+			return jsonResponse(req, http.StatusOK, `{}`), nil
+		}
+		return jsonResponse(req, http.StatusNotFound, `{}`), nil
+	}}
+	metrics := NewPrometheusObserver()
+	client := NewClient(WithTransport(transport), WithObserver(metrics))
+	// An expired access token is refreshed on the first request.
+	client.authenticate(&OAuth1Token{Token: "token"}, &AccessToken{
+		AccessToken:         "stale",
+		TokenType:           "Bearer",
+		RefreshTokenExpires: time.Now().Add(time.Hour).UnixMilli(),
+	})
+	var out map[string]any
+	for range 2 {
+		if err := client.apiGet(&out, "/activity-service/activity/123", nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := client.apiGet(&out, "/wellness-service/wellness/dailyStress/2024-08-16", nil); err == nil {
+		t.Fatal("expected an error for a 404")
+	}
+	if _, err := NewAPI(client).Wellness.DailySleep("jdoe", time.Date(2024, 8, 16, 0, 0, 0, 0, time.UTC)); err == nil {
+		t.Fatal("expected an error for a 404")
+	}
+	// Display names don't look like ids, the endpoint registry knows where
+	// they are.
+	if err := client.apiGet(&out, "/metrics-service/metrics/racepredictions/latest/jdoe", nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.apiGet(&out, "/new-service/thing/2024-08-16/42", nil); err == nil {
+		t.Fatal("expected an error for a 404")
+	}
+
+	rec := httptest.NewRecorder()
+	metrics.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	text := rec.Body.String()
+	for _, want := range []string{
+		`garmin_requests_total{method="GET",endpoint="/activity-service/activity/{id}",status="200"} 2`,
+		`garmin_requests_total{method="GET",endpoint="/wellness-service/wellness/dailyStress/{date}",status="404"} 1`,
+		`garmin_requests_total{method="GET",endpoint="/wellness-service/wellness/dailySleepData/{displayName}",status="404"} 1`,
+		`garmin_requests_total{method="GET",endpoint="/metrics-service/metrics/racepredictions/latest/{displayName}",status="200"} 1`,
+		`garmin_requests_total{method="GET",endpoint="/new-service/thing/{date}/{id}",status="404"} 1`,
+		`garmin_requests_total{method="POST",endpoint="/oauth-service/oauth/exchange/user/2.0",status="200"} 1`,
+		`garmin_response_bytes_total{method="GET",endpoint="/activity-service/activity/{id}"} 38`,
+		`garmin_token_refreshes_total{result="ok"} 1`,
+		`garmin_request_duration_seconds_count{method="GET",endpoint="/activity-service/activity/{id}"} 2`,
+		`garmin_request_duration_seconds_bucket{method="GET",endpoint="/activity-service/activity/{id}",le="+Inf"} 2`,
+		"garmin_requests_in_flight 0",
+		"# TYPE garmin_request_duration_seconds histogram",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("missing %s in:\n%s", want, text)
+		}
+	}
+}
+
+// stepClock moves a second forward every time it is read.
+type stepClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (sc *stepClock) Now() time.Time {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	sc.now = sc.now.Add(time.Second)
+	return sc.now
+}
+
+type latencyObserver struct {
+	requests []time.Duration
+	refresh  []time.Duration
+}
+
+func (lo *latencyObserver) RequestStart(*RequestEvent) {}
+func (lo *latencyObserver) RequestEnd(ev *RequestEvent) {
+	lo.requests = append(lo.requests, ev.Latency)
+}
+func (lo *latencyObserver) TokenRefresh(ev *RefreshEvent) {
+	lo.refresh = append(lo.refresh, ev.Latency)
+}
+
+func TestMetricsUseClock(t *testing.T) {
+	prev := getOAuthConsumer
+	getOAuthConsumer = func() (*oAuthConsumer, error) { return &oAuthConsumer{Key: "key", Secret: "secret"}, nil }
+	defer func() { getOAuthConsumer = prev }()
+
+	transport := &fakeTransport{fn: func(req *http.Request) (*http.Response, error) {
+		if req.URL.Path == "/oauth-service/oauth/exchange/user/2.0" {
+			return jsonResponse(req, http.StatusOK, `{"access_token": "fresh", "token_type": "Bearer", "expires_in": 3600}`), nil
+		}
+		return jsonResponse(req, http.StatusOK, `{}`), nil
+	}}
+	start := time.Date(2024, 8, 16, 0, 0, 0, 0, time.UTC)
+	observer := new(latencyObserver)
+	client := NewClient(WithTransport(transport), WithObserver(observer), WithClock(&stepClock{now: start}))
+	client.authenticate(&OAuth1Token{Token: "token"}, &AccessToken{
+		AccessToken:         "stale",
+		TokenType:           "Bearer",
+		RefreshTokenExpires: start.Add(time.Hour).UnixMilli(),
+	})
+	var out map[string]any
+	if err := client.apiGet(&out, "/activity-service/activity/123", nil); err != nil {
+		t.Fatal(err)
+	}
+	// Every latency is a whole number of the clock's seconds, real time
+	// would have left a fraction.
+	for _, l := range append(observer.requests, observer.refresh...) {
+		if l <= 0 || l%time.Second != 0 {
+			t.Errorf("latency %v is not from the client clock", l)
+		}
+	}
+	if len(observer.requests) != 2 || len(observer.refresh) != 1 {
+		t.Errorf("requests %v, refreshes %v", observer.requests, observer.refresh)
+	}
+}
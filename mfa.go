@@ -0,0 +1,217 @@
+package garmin
+
+import (
+	"bufio"
+	"context"
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/base32"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// ErrInvalidMFACode is returned when Garmin rejected every MFA code given
+// during a login.
+var ErrInvalidMFACode = errors.New("invalid MFA code")
+
+// MaxMFAAttempts is how many codes are tried before a login fails with
+// ErrInvalidMFACode.
+const MaxMFAAttempts = 3
+
+// MFAMethod is where Garmin sent the MFA code.
+type MFAMethod string
+
+const (
+	MFAMethodUnknown MFAMethod = ""
+	MFAMethodEmail   MFAMethod = "email"
+	MFAMethodSMS     MFAMethod = "sms"
+	MFAMethodApp     MFAMethod = "app"
+)
+
+// MFAChallenge describes one request for an MFA code.
+type MFAChallenge struct {
+	Method MFAMethod
+	// Destination is the masked email address or phone number the code was
+	// sent to, when Garmin shows it.
+	Destination string
+	// Attempt starts at 1 and goes up every time Garmin rejects a code.
+	Attempt     int
+	MaxAttempts int
+}
+
+// MFAProvider supplies MFA codes during Login.
+type MFAProvider interface {
+	MFACode(ctx context.Context, ch *MFAChallenge) (string, error)
+}
+
+// MFAProviderFunc lets a plain function be used as an MFAProvider, which is
+// the simplest way to plug in a callback.
+type MFAProviderFunc func(ctx context.Context, ch *MFAChallenge) (string, error)
+
+func (fn MFAProviderFunc) MFACode(ctx context.Context, ch *MFAChallenge) (string, error) {
+	return fn(ctx, ch)
+}
+
+// mfaHandlerProvider adapts the older MFAHandler function.
+func mfaHandlerProvider(fn func() (string, error)) MFAProvider {
+	return MFAProviderFunc(func(context.Context, *MFAChallenge) (string, error) { return fn() })
+}
+
+var (
+	mfaEmailRe = regexp.MustCompile(`(?i)\b[\w.*+-]*\*[\w.*+-]*@[\w.*-]+\.[a-z]{2,}\b`)
+	mfaPhoneRe = regexp.MustCompile(`[+(]?[\d*][\d*() -]{5,}\d{2}`)
+)
+
+// parseMFAChallenge guesses the MFA method from the MFA page Garmin sends.
+func parseMFAChallenge(page []byte, attempt int) *MFAChallenge {
+	ch := MFAChallenge{Attempt: attempt, MaxAttempts: MaxMFAAttempts}
+	text := strings.ToLower(string(page))
+	switch {
+	case strings.Contains(text, "authenticator"):
+		ch.Method = MFAMethodApp
+	case strings.Contains(text, "text message") || strings.Contains(text, "sms") || strings.Contains(text, "phone"):
+		ch.Method = MFAMethodSMS
+		if m := mfaPhoneRe.Find(page); m != nil && strings.Contains(string(m), "*") {
+			ch.Destination = string(m)
+		}
+	case strings.Contains(text, "email"):
+		ch.Method = MFAMethodEmail
+		if m := mfaEmailRe.Find(page); m != nil {
+			ch.Destination = string(m)
+		}
+	}
+	return &ch
+}
+
+// TerminalMFA prompts for the code on Out and reads it from In, usually
+// os.Stdout and os.Stdin.
+type TerminalMFA struct {
+	In  io.Reader
+	Out io.Writer
+
+	// in buffers In across calls, so that a retry reads the next line.
+	in *bufio.Reader
+}
+
+func (tm *TerminalMFA) MFACode(ctx context.Context, ch *MFAChallenge) (string, error) {
+	var where string
+	switch ch.Method {
+	case MFAMethodEmail, MFAMethodSMS:
+		where = " sent by " + string(ch.Method)
+		if len(ch.Destination) > 0 {
+			where += " to " + ch.Destination
+		}
+	case MFAMethodApp:
+		where = " from your authenticator app"
+	}
+	if ch.Attempt > 1 {
+		fmt.Fprintf(tm.Out, "The code was not accepted (attempt %d of %d).\n", ch.Attempt, ch.MaxAttempts)
+	}
+	fmt.Fprintf(tm.Out, "Enter the MFA code%s: ", where)
+	if tm.in == nil {
+		tm.in = bufio.NewReader(tm.In)
+	}
+	line, err := tm.in.ReadString('\n')
+	if err != nil && !(errors.Is(err, io.EOF) && len(line) > 0) {
+		return "", err
+	}
+	return strings.TrimSpace(line), nil
+}
+
+// TOTPMFA generates codes from an authenticator app secret (RFC 6238 with
+// SHA-1, 30 second steps and 6 digits, which is what Garmin uses).
+type TOTPMFA struct {
+	// Secret is the base32 secret shown when setting up the authenticator.
+	Secret string
+	// Clock defaults to DefaultClock.
+	Clock Clock
+
+	lastStep uint64
+}
+
+func (tm *TOTPMFA) MFACode(ctx context.Context, ch *MFAChallenge) (string, error) {
+	clock := tm.Clock
+	if clock == nil {
+		clock = &DefaultClock
+	}
+	now := clock.Now()
+	// A rejected code won't be accepted again, wait for the next one.
+	if ch.Attempt > 1 && totpStep(now) <= tm.lastStep {
+		next := time.Unix(int64(tm.lastStep+1)*totpPeriod, 0)
+		select {
+		case <-ctx.Done():
+			return "", ctx.Err()
+		case <-time.After(next.Sub(now)):
+		}
+		now = next
+	}
+	tm.lastStep = totpStep(now)
+	return TOTP(tm.Secret, now)
+}
+
+const totpPeriod = 30
+
+func totpStep(t time.Time) uint64 { return uint64(t.Unix() / totpPeriod) }
+
+// TOTP returns the 6 digit RFC 6238 code for the base32 secret at time t.
+func TOTP(secret string, t time.Time) (string, error) {
+	secret = strings.ToUpper(strings.ReplaceAll(secret, " ", ""))
+	key, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(strings.TrimRight(secret, "="))
+	if err != nil {
+		return "", fmt.Errorf("invalid TOTP secret: %w", err)
+	}
+	var msg [8]byte
+	binary.BigEndian.PutUint64(msg[:], totpStep(t))
+	mac := hmac.New(sha1.New, key)
+	mac.Write(msg[:])
+	sum := mac.Sum(nil)
+	offset := sum[len(sum)-1] & 0x0F
+	code := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7FFFFFFF
+	return fmt.Sprintf("%06d", code%1_000_000), nil
+}
+
+// ChannelMFA hands challenges to another goroutine, for example a web
+// handler that asks the user for the code, and waits for the answer.
+//
+//	mfa := garmin.NewChannelMFA()
+//	client := garmin.NewClient(garmin.WithMFAProvider(mfa))
+//	go func() { errc <- client.Login(email, password) }()
+//	ch := <-mfa.Challenges // show a form to the user
+//	mfa.Codes <- code      // from the submitted form
+type ChannelMFA struct {
+	Challenges chan *MFAChallenge
+	Codes      chan string
+	// Timeout bounds the wait for a code, zero means wait forever.
+	Timeout time.Duration
+}
+
+func NewChannelMFA() *ChannelMFA {
+	return &ChannelMFA{
+		Challenges: make(chan *MFAChallenge, 1),
+		Codes:      make(chan string),
+	}
+}
+
+func (cm *ChannelMFA) MFACode(ctx context.Context, ch *MFAChallenge) (string, error) {
+	if cm.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, cm.Timeout)
+		defer cancel()
+	}
+	select {
+	case cm.Challenges <- ch:
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
+	select {
+	case code := <-cm.Codes:
+		return code, nil
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
+}
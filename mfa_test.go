@@ -0,0 +1,152 @@
+package garmin
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTOTP(t *testing.T) {
+	// RFC 6238 appendix B, SHA-1, truncated to 6 digits.
+	secret := "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+	for _, tt := range []struct {
+		unix int64
+		want string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1234567890, "005924"},
+		{20000000000, "353130"},
+	} {
+		got, err := TOTP(secret, time.Unix(tt.unix, 0))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != tt.want {
+			t.Errorf("TOTP at %d: got %s, want %s", tt.unix, got, tt.want)
+		}
+	}
+	if _, err := TOTP("not base32!", time.Now()); err == nil {
+		t.Error("expected an error for an invalid secret")
+	}
+}
+
+func TestParseMFAChallenge(t *testing.T) {
+	ch := parseMFAChallenge([]byte(`<p>We sent a security code to your email j***n@g****.com.</p>`), 2)
+	if ch.Method != MFAMethodEmail || ch.Destination != "j***n@g****.com" || ch.Attempt != 2 {
+		t.Errorf("email: got %+v", ch)
+	}
+	ch = parseMFAChallenge([]byte(`<p>Enter the code sent by text message to +1 ***-***-1234</p>`), 1)
+	if ch.Method != MFAMethodSMS || ch.Destination != "+1 ***-***-1234" {
+		t.Errorf("sms: got %+v", ch)
+	}
+}
+
+func TestHandleMFA(t *testing.T) {
+	mfaPage := func(csrf string) string {
+		return `<title>Enter MFA code for login</title><input type="hidden" name="_csrf" value="` + csrf + `" />`
+	}
+	var codes, csrfs []string
+	transport := &fakeTransport{fn: func(req *http.Request) (*http.Response, error) {
+		b, _ := io.ReadAll(req.Body)
+		form, _ := url.ParseQuery(string(b))
+		codes = append(codes, form.Get("mfa-code"))
+		csrfs = append(csrfs, form.Get("_csrf"))
+		body := mfaPage("csrf-" + form.Get("mfa-code"))
+		if form.Get("mfa-code") == "222222" {
+			body = `<title>Success</title>`
+		}
+		res := jsonResponse(req, http.StatusOK, body)
+		res.Header.Set("Content-Type", "text/html")
+		return res, nil
+	}}
+
+	var challenges []MFAChallenge
+	provider := MFAProviderFunc(func(_ context.Context, ch *MFAChallenge) (string, error) {
+		challenges = append(challenges, *ch)
+		return strings.Repeat(string(rune('0'+ch.Attempt)), 6), nil
+	})
+	client := NewClient(WithTransport(transport), WithMFAProvider(provider))
+	oc := &oauthClient{client: client, csrf: "csrf-0"}
+	oc.buf.WriteString(mfaPage("csrf-0"))
+
+	title, err := oc.handleMFA()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if title != "Success" {
+		t.Errorf("title: got %q", title)
+	}
+	if strings.Join(codes, ",") != "111111,222222" || strings.Join(csrfs, ",") != "csrf-0,csrf-111111" {
+		t.Errorf("got codes %v with csrf tokens %v", codes, csrfs)
+	}
+	if len(challenges) != 2 || challenges[1].Attempt != 2 || challenges[1].MaxAttempts != MaxMFAAttempts {
+		t.Errorf("challenges: %+v", challenges)
+	}
+
+	t.Run("GiveUp", func(t *testing.T) {
+		codes = nil
+		client.MFAProvider = nil
+		client.MFAHandler = func() (string, error) { return "000000", nil }
+		oc.buf.Reset()
+		oc.buf.WriteString(mfaPage("csrf-0"))
+		if _, err := oc.handleMFA(); !errors.Is(err, ErrInvalidMFACode) {
+			t.Errorf("want %v, got %v", ErrInvalidMFACode, err)
+		}
+		if len(codes) != MaxMFAAttempts {
+			t.Errorf("expected %d attempts, got %d", MaxMFAAttempts, len(codes))
+		}
+	})
+}
+
+func TestTerminalMFA(t *testing.T) {
+	var out bytes.Buffer
+	tm := &TerminalMFA{In: strings.NewReader(" 111111\n222222\n"), Out: &out}
+	code, err := tm.MFACode(context.Background(), &MFAChallenge{Method: MFAMethodEmail, Attempt: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if code != "111111" {
+		t.Errorf("code: got %q", code)
+	}
+	if !strings.Contains(out.String(), "sent by email") {
+		t.Errorf("prompt: got %q", out.String())
+	}
+	// The retry reads the next line.
+	code, err = tm.MFACode(context.Background(), &MFAChallenge{Method: MFAMethodEmail, Attempt: 2, MaxAttempts: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if code != "222222" {
+		t.Errorf("retry code: got %q", code)
+	}
+	if !strings.Contains(out.String(), "attempt 2 of 3") {
+		t.Errorf("retry prompt: got %q", out.String())
+	}
+}
+
+func TestChannelMFA(t *testing.T) {
+	cm := NewChannelMFA()
+	go func() {
+		ch := <-cm.Challenges
+		if ch.Attempt == 1 {
+			cm.Codes <- "654321"
+		}
+	}()
+	code, err := cm.MFACode(context.Background(), &MFAChallenge{Attempt: 1})
+	if err != nil || code != "654321" {
+		t.Errorf("got %q, %v", code, err)
+	}
+
+	cm.Timeout = 10 * time.Millisecond
+	go func() { <-cm.Challenges }()
+	if _, err := cm.MFACode(context.Background(), &MFAChallenge{Attempt: 1}); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("want %v, got %v", context.DeadlineExceeded, err)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
 	"net/url"
 	"regexp"
@@ -32,6 +33,9 @@ var (
 	ErrNotSuccessful       = errors.New("page title was not \"Success\"")
 	ErrExpiredRefreshToken = errors.New("refresh token has expired")
 	ErrAccessTokenExpired  = errors.New("access_token has expired")
+	// ErrReauthRequired means the OAuth1 token is missing or was revoked and
+	// the user has to log in with their password again.
+	ErrReauthRequired = errors.New("oauth1 token is not valid, login required")
 )
 
 type oAuthConsumer struct {
@@ -104,18 +108,31 @@ func login(client *Client, username, password string) (*oauth1.Token, *AccessTok
 			},
 		}
 	)
+	log := client.logger()
 	if client.Cacher != nil {
 		ot, at, err := getCachedPair(client.Cacher)
-		if err == nil && !at.expired() {
+		if err == nil && !client.needsRefresh(at) {
+			log.Debug("login: using cached tokens", slog.Time("expires", at.ExpiresAt()))
 			return ot, at, nil
 		}
+		// The OAuth1 token outlives the access token, try to get a new access
+		// token with it before asking for the password again.
+		if ot != nil && len(ot.Token) > 0 {
+			refresher := oauth1TokenRefresher{token: ot, client: client}
+			if at, err = refresher.Refresh(at); err == nil {
+				return ot, at, nil
+			}
+			log.Info("login: cached oauth1 token was not accepted, signing in", slog.Any("error", err))
+		}
 	}
 
 	if err = oc.getCSRF(); err != nil {
 		return nil, nil, err
 	}
+	log.Debug("login: signing in", slog.String("domain", client.Domain))
 	ticket, err := oc.signin(username, password)
 	if err != nil {
+		log.Warn("login: sign in failed", slog.Any("error", err))
 		return nil, nil, err
 	}
 	// Get tokens
@@ -134,6 +151,7 @@ func login(client *Client, username, password string) (*oauth1.Token, *AccessTok
 	if err != nil {
 		return nil, nil, err
 	}
+	log.Info("login: signed in", slog.Time("expires", accessToken.ExpiresAt()))
 	if client.Cacher != nil {
 		if err = client.Cacher.SaveOAuth1Token(token); err != nil {
 			return token, accessToken, err
@@ -210,19 +228,48 @@ func (oc *oauthClient) signin(username, password string) (ticket string, err err
 }
 
 func (oc *oauthClient) handleMFA() (string, error) {
-	if oc.client.MFAHandler == nil {
+	provider := oc.client.MFAProvider
+	if provider == nil && oc.client.MFAHandler != nil {
+		provider = mfaHandlerProvider(oc.client.MFAHandler)
+	}
+	if provider == nil {
 		return "", errors.New("no MFA handler specified, cannot get MFA code")
 	}
-	code, err := oc.client.MFAHandler()
-	if err != nil {
-		return "", err
+	log := oc.client.logger()
+	for attempt := 1; attempt <= MaxMFAAttempts; attempt++ {
+		challenge := parseMFAChallenge(oc.buf.Bytes(), attempt)
+		log.Info("login: MFA code required", slog.String("method", string(challenge.Method)), slog.Int("attempt", attempt))
+		code, err := provider.MFACode(context.Background(), challenge)
+		if err != nil {
+			return "", err
+		}
+		title, err := oc.verifyMFA(code)
+		if err != nil {
+			return "", err
+		}
+		// A rejected code sends the MFA page again, with a new csrf token.
+		if !strings.Contains(title, "MFA") {
+			return title, nil
+		}
+		log.Warn("login: MFA code was rejected", slog.Int("attempt", attempt))
+		if csrf, err := findCSRF(oc.buf.Bytes()); err == nil {
+			oc.csrf = csrf
+		}
 	}
+	return "", fmt.Errorf("%w: gave up after %d attempts", ErrInvalidMFACode, MaxMFAAttempts)
+}
+
+func (oc *oauthClient) verifyMFA(code string) (string, error) {
 	data := url.Values{
 		"mfa-code": []string{code},
 		"_csrf":    []string{oc.csrf},
 		"embed":    []string{"true"},
 		"fromPage": []string{"setupEnterMfaCode"},
 	}
+	header := http.Header{"Content-Type": []string{formContentType}}
+	if oc.client.prev != nil {
+		header.Set("Referer", oc.client.prev.Request.URL.String())
+	}
 	res, err := oc.client.Do(&http.Request{
 		Method: "POST",
 		URL: new(URLBuilder).
@@ -231,11 +278,8 @@ func (oc *oauthClient) handleMFA() (string, error) {
 			Path("/sso/verifyMFA/loginEnterMfaCode").
 			Query(oc.signinParams).
 			URL(),
-		Body: io.NopCloser(strings.NewReader(data.Encode())),
-		Header: http.Header{
-			"Referer":      []string{oc.client.prev.Request.URL.String()},
-			"Content-Type": []string{formContentType},
-		},
+		Body:   io.NopCloser(strings.NewReader(data.Encode())),
+		Header: header,
 	})
 	if err != nil {
 		return "", err
@@ -261,12 +305,12 @@ type AccessToken struct {
 	RefreshTokenExpiresIn int    `json:"refresh_token_expires_in"`
 }
 
-func (at *AccessToken) expired() bool {
-	return time.Now().After(at.ExpiresAt())
+func (at *AccessToken) expired(now time.Time) bool {
+	return now.After(at.ExpiresAt())
 }
 
-func (at *AccessToken) refreshExpired() bool {
-	return time.Now().After(at.RefreshTokenExpiresAt())
+func (at *AccessToken) refreshExpired(now time.Time) bool {
+	return now.After(at.RefreshTokenExpiresAt())
 }
 
 func (at *AccessToken) setExpirations(now time.Time) {
@@ -311,13 +355,17 @@ func exchange(client *Client, conf *oauth1.Config, token *oauth1.Token) (*Access
 		Header: http.Header{"Content-Type": []string{formContentType}},
 		Body:   io.NopCloser(strings.NewReader(body.Encode())),
 	}
-	res, err := c.Do(&req)
+	res, err := c.Do(req.WithContext(withoutAccessToken(context.Background())))
 	if err != nil {
 		return nil, err
 	}
-	now := time.Now()
+	now := client.now()
 	defer res.Body.Close()
-	if res.StatusCode != http.StatusOK {
+	switch res.StatusCode {
+	case http.StatusOK:
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return nil, fmt.Errorf("%w: %s", ErrReauthRequired, res.Status)
+	default:
 		return nil, errors.New(res.Status)
 	}
 	var at AccessToken
@@ -332,9 +380,26 @@ type Refresher interface {
 	Refresh(*AccessToken) (*AccessToken, error)
 }
 
+// noAccessTokenKey marks requests that accessTokenInjector must pass through
+// untouched.
+type noAccessTokenKey struct{}
+
+// withoutAccessToken marks requests made with ctx as authenticated some other
+// way, such as the OAuth1 signed exchange for a new access token. Without the
+// mark the exchange, which goes through the client's transport too, would try
+// to refresh the token it is fetching.
+func withoutAccessToken(ctx context.Context) context.Context {
+	return context.WithValue(ctx, noAccessTokenKey{}, true)
+}
+
 type accessTokenInjector struct {
+	// mu guards AccessToken and refresher, and is held while refreshing so
+	// that concurrent requests wait for one refresh instead of each starting
+	// their own.
+	mu          sync.Mutex
 	AccessToken *AccessToken
 	refresher   Refresher
+	client      *Client
 	base        http.RoundTripper
 }
 
@@ -345,24 +410,59 @@ func (ati *accessTokenInjector) Wrap(rt http.RoundTripper) rt.RoundTripper {
 
 func (ati *accessTokenInjector) Unwrap() http.RoundTripper { return ati.base }
 
+// set replaces the tokens used for requests.
+func (ati *accessTokenInjector) set(access *AccessToken, refresher Refresher) {
+	ati.mu.Lock()
+	defer ati.mu.Unlock()
+	ati.AccessToken = access
+	ati.refresher = refresher
+}
+
+// tokens returns the tokens used for requests.
+func (ati *accessTokenInjector) tokens() (*AccessToken, Refresher) {
+	ati.mu.Lock()
+	defer ati.mu.Unlock()
+	return ati.AccessToken, ati.refresher
+}
+
 func (ati *accessTokenInjector) RoundTrip(req *http.Request) (*http.Response, error) {
-	if ati.AccessToken.expired() {
-		if ati.AccessToken.refreshExpired() {
-			return nil, ErrExpiredRefreshToken
-		}
-		at, err := ati.refresher.Refresh(ati.AccessToken)
-		if err != nil {
-			return nil, err
-		}
-		ati.AccessToken = at
+	if skip, _ := req.Context().Value(noAccessTokenKey{}).(bool); skip {
+		return ati.base.RoundTrip(req)
+	}
+	at, err := ati.current()
+	if err != nil {
+		return nil, err
 	}
 	if req.Header == nil {
 		req.Header = make(http.Header)
 	}
-	req.Header.Set(authHeader, fmt.Sprintf("%s %s", ati.AccessToken.TokenType, ati.AccessToken.AccessToken))
+	req.Header.Set(authHeader, fmt.Sprintf("%s %s", at.TokenType, at.AccessToken))
 	return ati.base.RoundTrip(req)
 }
 
+// current returns the access token, refreshing it first if it is about to
+// expire.
+func (ati *accessTokenInjector) current() (*AccessToken, error) {
+	ati.mu.Lock()
+	defer ati.mu.Unlock()
+	if !ati.client.needsRefresh(ati.AccessToken) {
+		return ati.AccessToken, nil
+	}
+	log := ati.client.logger()
+	if ati.AccessToken.refreshExpired(ati.client.now()) {
+		log.Warn("refresh token has expired", slog.Time("expired", ati.AccessToken.RefreshTokenExpiresAt()))
+		return nil, ErrExpiredRefreshToken
+	}
+	log.Debug("refreshing access token", slog.Time("expires", ati.AccessToken.ExpiresAt()))
+	at, err := ati.refresher.Refresh(ati.AccessToken)
+	if err != nil {
+		log.Warn("refreshing access token failed", slog.Any("error", err))
+		return nil, err
+	}
+	ati.AccessToken = at
+	return at, nil
+}
+
 type oauth1TokenRefresher struct {
 	token  *oauth1.Token
 	client *Client
@@ -373,16 +473,18 @@ func (otr *oauth1TokenRefresher) Refresh(*AccessToken) (*AccessToken, error) {
 	if err != nil {
 		return nil, err
 	}
+	start := otr.client.now()
 	accessToken, err := exchange(otr.client, conf, otr.token)
+	otr.client.observeRefresh(&RefreshEvent{Latency: otr.client.now().Sub(start), Err: err})
 	if err != nil {
 		return nil, err
 	}
 	if otr.client.Cacher != nil {
-		if err = otr.client.Cacher.SaveOAuth1Token(otr.token); err != nil {
-			return accessToken, err
-		}
-		if err = otr.client.Cacher.SaveAccessToken(accessToken); err != nil {
-			return accessToken, err
+		// The new token is valid whether or not it could be saved, failing
+		// here would only make every request exchange for another one.
+		err = errors.Join(otr.client.Cacher.SaveOAuth1Token(otr.token), otr.client.Cacher.SaveAccessToken(accessToken))
+		if err != nil {
+			otr.client.logger().Warn("saving refreshed tokens failed", slog.Any("error", err))
 		}
 	}
 	return accessToken, nil
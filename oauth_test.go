@@ -2,13 +2,18 @@ package garmin
 
 import (
 	"bufio"
+	"bytes"
+	"context"
 	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"log/slog"
 	"net/http"
 	"os"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -122,10 +127,16 @@ func TestAccessToken(t *testing.T) {
 		RefreshTokenExpiresIn: 7199,
 		RefreshTokenExpires:   1723682908817,
 	}
-	if !at.expired() {
+	if !at.expired(time.Now()) {
 		t.Error("access token should be marked as expired")
 	}
-	_ = at.refreshExpired() // TODO check this
+	issued := time.UnixMilli(at.Expires).Add(-time.Duration(at.ExpiresIn) * time.Second)
+	if at.expired(issued) || at.refreshExpired(issued) {
+		t.Error("access token should not be expired when issued")
+	}
+	if !at.refreshExpired(at.RefreshTokenExpiresAt().Add(time.Millisecond)) {
+		t.Error("refresh token should be marked as expired")
+	}
 }
 
 func TestAccessTokenInjector(t *testing.T) {
@@ -134,26 +145,37 @@ func TestAccessTokenInjector(t *testing.T) {
 		exp string
 		err error
 	}
+	now := time.Date(2024, 8, 14, 12, 0, 0, 0, time.UTC)
+	client := NewClient(WithClock(fakeClock(now)), WithRefreshMargin(time.Minute))
 	for _, tt := range []TT{
 		{
 			at: AccessToken{
-				Expires:             time.Now().Add(time.Hour * 24).UnixMilli(),
-				RefreshTokenExpires: time.Now().Add(time.Hour * 48).UnixMilli(),
+				Expires:             now.Add(time.Hour * 24).UnixMilli(),
+				RefreshTokenExpires: now.Add(time.Hour * 48).UnixMilli(),
 			},
 			exp: "Bearer access-token0",
 			err: nil,
 		},
 		{
 			at: AccessToken{
-				Expires:             time.Now().Add(-1 * time.Hour).UnixMilli(),
-				RefreshTokenExpires: time.Now().Add(time.Hour * 48).UnixMilli(),
+				Expires:             now.Add(-1 * time.Hour).UnixMilli(),
+				RefreshTokenExpires: now.Add(time.Hour * 48).UnixMilli(),
 			},
 			exp: "Bearer refreshed-access-token1",
 			err: nil,
 		},
 		{
+			// Within the refresh margin.
 			at: AccessToken{
-				RefreshTokenExpires: time.Now().Add(-1 * time.Hour * 48).UnixMilli(),
+				Expires:             now.Add(30 * time.Second).UnixMilli(),
+				RefreshTokenExpires: now.Add(time.Hour * 48).UnixMilli(),
+			},
+			exp: "Bearer refreshed-access-token1",
+			err: nil,
+		},
+		{
+			at: AccessToken{
+				RefreshTokenExpires: now.Add(-1 * time.Hour * 48).UnixMilli(),
 			},
 			exp: "",
 			err: ErrExpiredRefreshToken,
@@ -163,6 +185,7 @@ func TestAccessTokenInjector(t *testing.T) {
 		tt.at.TokenType = "Bearer"
 		ij := accessTokenInjector{
 			AccessToken: &tt.at,
+			client:      client,
 			refresher: refresherFunc(func(at *AccessToken) (*AccessToken, error) {
 				var refed = *at
 				refed.AccessToken = "refreshed-access-token1"
@@ -177,12 +200,87 @@ func TestAccessTokenInjector(t *testing.T) {
 		if !errors.Is(err, tt.err) {
 			t.Fatalf("want error %v, got error %v", tt.err, err)
 		}
-		if a := req.Header.Get(authHeader); a != "" && a != tt.exp {
+		if a := req.Header.Get(authHeader); a != tt.exp {
 			t.Errorf("authorization header: got %q, want %q", a, tt.exp)
 		}
 	}
 }
 
+func TestAccessTokenInjectorMarkedRequests(t *testing.T) {
+	now := time.Date(2024, 8, 14, 12, 0, 0, 0, time.UTC)
+	ij := accessTokenInjector{
+		AccessToken: &AccessToken{
+			AccessToken:         "access-token0",
+			TokenType:           "Bearer",
+			Expires:             now.Add(time.Hour).UnixMilli(),
+			RefreshTokenExpires: now.Add(2 * time.Hour).UnixMilli(),
+		},
+		client: NewClient(WithClock(fakeClock(now))),
+		base: rt.RoundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			return &http.Response{Request: r}, nil
+		}),
+	}
+	// A caller's own Authorization header is not a reason to skip the token.
+	req := &http.Request{Header: http.Header{authHeader: []string{"Basic x"}}}
+	if _, err := ij.RoundTrip(req); err != nil {
+		t.Fatal(err)
+	}
+	if a := req.Header.Get(authHeader); a != "Bearer access-token0" {
+		t.Errorf("unmarked request: got %q", a)
+	}
+	req = (&http.Request{Header: http.Header{authHeader: []string{"OAuth x"}}}).
+		WithContext(withoutAccessToken(context.Background()))
+	if _, err := ij.RoundTrip(req); err != nil {
+		t.Fatal(err)
+	}
+	if a := req.Header.Get(authHeader); a != "OAuth x" {
+		t.Errorf("marked request: got %q", a)
+	}
+}
+
+func TestAccessTokenInjectorConcurrentRefresh(t *testing.T) {
+	now := time.Date(2024, 8, 14, 12, 0, 0, 0, time.UTC)
+	var refreshes atomic.Int32
+	ij := accessTokenInjector{
+		AccessToken: &AccessToken{
+			AccessToken:         "stale",
+			TokenType:           "Bearer",
+			Expires:             now.Add(-time.Minute).UnixMilli(),
+			RefreshTokenExpires: now.Add(time.Hour).UnixMilli(),
+		},
+		client: NewClient(WithClock(fakeClock(now))),
+		refresher: refresherFunc(func(at *AccessToken) (*AccessToken, error) {
+			refreshes.Add(1)
+			fresh := *at
+			fresh.AccessToken = "fresh"
+			fresh.Expires = now.Add(time.Hour).UnixMilli()
+			return &fresh, nil
+		}),
+		base: rt.RoundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			return &http.Response{Request: r}, nil
+		}),
+	}
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			req := &http.Request{Header: make(http.Header)}
+			if _, err := ij.RoundTrip(req); err != nil {
+				t.Error(err)
+				return
+			}
+			if a := req.Header.Get(authHeader); a != "Bearer fresh" {
+				t.Errorf("authorization header: got %q", a)
+			}
+		}()
+	}
+	wg.Wait()
+	if n := refreshes.Load(); n != 1 {
+		t.Errorf("got %d refreshes, want 1", n)
+	}
+}
+
 func TestDates(t *testing.T) {
 	t.Skip()
 	const dateFormat = "2006-01-02T15:04:05.99"
@@ -257,3 +355,211 @@ func b64strip(m any, enc *base64.Encoding) string {
 type refresherFunc func(*AccessToken) (*AccessToken, error)
 
 func (fn refresherFunc) Refresh(at *AccessToken) (*AccessToken, error) { return fn(at) }
+
+func TestResume(t *testing.T) {
+	prev := getOAuthConsumer
+	getOAuthConsumer = func() (*oAuthConsumer, error) { return &oAuthConsumer{Key: "key", Secret: "secret"}, nil }
+	defer func() { getOAuthConsumer = prev }()
+
+	revoked := false
+	transport := &fakeTransport{fn: func(req *http.Request) (*http.Response, error) {
+		switch {
+		case req.URL.Path == "/oauth-service/oauth/exchange/user/2.0" && revoked:
+			return jsonResponse(req, http.StatusUnauthorized, `{}`), nil
+		case req.URL.Path == "/oauth-service/oauth/exchange/user/2.0":
+			return jsonResponse(req, http.StatusOK, `{
+				"access_token": "fresh", "token_type": "Bearer",
+				"expires_in": 3600, "refresh_token_expires_in": 7200
+			}`), nil
+		default:
+			return jsonResponse(req, http.StatusOK, `{"weight": 80000, "auth": "`+req.Header.Get(authHeader)+`"}`), nil
+		}
+	}}
+
+	cacher := new(InMemTokenCacher)
+	client := NewClient(WithCacher(cacher), WithTransport(transport))
+	if err := client.Resume(); !errors.Is(err, ErrReauthRequired) {
+		t.Fatalf("empty cache: want %v, got %v", ErrReauthRequired, err)
+	}
+
+	_ = cacher.SaveOAuth1Token(&OAuth1Token{Token: "token", TokenSecret: "secret"})
+	_ = cacher.SaveAccessToken(&AccessToken{AccessToken: "stale", TokenType: "Bearer"})
+	if err := client.Resume(); err != nil {
+		t.Fatal(err)
+	}
+	at, err := cacher.GetAccessToken()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if at.AccessToken != "fresh" || client.needsRefresh(at) {
+		t.Errorf("expected a fresh access token in the cache, got %+v", at)
+	}
+	var out struct {
+		Auth string `json:"auth"`
+	}
+	if err = client.apiGet(&out, "/weight-service/weight/first", nil); err != nil {
+		t.Fatal(err)
+	}
+	if out.Auth != "Bearer fresh" {
+		t.Errorf("authorization header: got %q", out.Auth)
+	}
+
+	revoked = true
+	err = NewClient(WithTransport(transport)).LoginWithTokens(&OAuth1Token{Token: "token"}, nil)
+	if !errors.Is(err, ErrReauthRequired) {
+		t.Errorf("revoked token: want %v, got %v", ErrReauthRequired, err)
+	}
+
+	errBroken := errors.New("broken token store")
+	err = NewClient(WithCacher(&failingCacher{err: errBroken})).Resume()
+	if !errors.Is(err, errBroken) || errors.Is(err, ErrReauthRequired) {
+		t.Errorf("broken cacher: want %v, got %v", errBroken, err)
+	}
+}
+
+// failingCacher fails to read or save any token.
+type failingCacher struct {
+	InMemTokenCacher
+	err error
+}
+
+func (fc *failingCacher) GetOAuth1Token() (*OAuth1Token, error) { return nil, fc.err }
+func (fc *failingCacher) SaveOAuth1Token(*OAuth1Token) error    { return fc.err }
+func (fc *failingCacher) SaveAccessToken(*AccessToken) error    { return fc.err }
+
+func TestRefreshSaveFailure(t *testing.T) {
+	prev := getOAuthConsumer
+	getOAuthConsumer = func() (*oAuthConsumer, error) { return &oAuthConsumer{Key: "key", Secret: "secret"}, nil }
+	defer func() { getOAuthConsumer = prev }()
+
+	exchanges := 0
+	transport := &fakeTransport{fn: func(req *http.Request) (*http.Response, error) {
+		if req.URL.Path == "/oauth-service/oauth/exchange/user/2.0" {
+			exchanges++
+			return jsonResponse(req, http.StatusOK, `{
+				"access_token": "fresh", "token_type": "Bearer",
+				"expires_in": 3600, "refresh_token_expires_in": 7200
+			}`), nil
+		}
+		return jsonResponse(req, http.StatusOK, `{"auth": "`+req.Header.Get(authHeader)+`"}`), nil
+	}}
+	now := time.Date(2024, 8, 14, 12, 0, 0, 0, time.UTC)
+	client := NewClient(WithCacher(&failingCacher{err: errors.New("disk full")}), WithTransport(transport), WithClock(fakeClock(now)))
+	client.authenticate(&OAuth1Token{Token: "token"}, &AccessToken{
+		AccessToken: "stale", TokenType: "Bearer",
+		Expires: now.Add(-time.Minute).UnixMilli(), RefreshTokenExpires: now.Add(time.Hour).UnixMilli(),
+	})
+	for i := range 2 {
+		var out struct {
+			Auth string `json:"auth"`
+		}
+		if err := client.apiGet(&out, "/weight-service/weight/first", nil); err != nil {
+			t.Fatalf("request %d: %v", i, err)
+		}
+		if out.Auth != "Bearer fresh" {
+			t.Errorf("request %d: authorization header %q", i, out.Auth)
+		}
+	}
+	if exchanges != 1 {
+		t.Errorf("expected one exchange, got %d", exchanges)
+	}
+}
+
+func TestLogout(t *testing.T) {
+	var paths []string
+	transport := &fakeTransport{fn: func(req *http.Request) (*http.Response, error) {
+		paths = append(paths, req.URL.Path)
+		cookie := ""
+		if c, err := req.Cookie("session"); err == nil {
+			cookie = c.Value
+		}
+		res := jsonResponse(req, http.StatusOK, `{"auth": "`+req.Header.Get(authHeader)+`", "cookie": "`+cookie+`"}`)
+		res.Header.Set("Set-Cookie", "session=abc; Path=/")
+		return res, nil
+	}}
+	var out struct {
+		Auth   string `json:"auth"`
+		Cookie string `json:"cookie"`
+	}
+	cacher := cachedTokens("first")
+	client := NewClient(WithCacher(cacher), WithTransport(transport))
+	if err := client.Resume(); err != nil {
+		t.Fatal(err)
+	}
+	for range 2 {
+		if err := client.apiGet(&out, "/weight-service/weight/first", nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if out.Auth != "Bearer first" || out.Cookie != "abc" {
+		t.Fatalf("before logout: got %+v", out)
+	}
+
+	if err := client.Logout(WithSSOLogout()); err != nil {
+		t.Fatal(err)
+	}
+	if p := paths[len(paths)-1]; p != "/sso/logout" {
+		t.Errorf("expected an sso logout request, got %q", p)
+	}
+	if _, err := cacher.GetAccessToken(); !errors.Is(err, ErrTokenCacheNotFound) {
+		t.Errorf("access token should be deleted, got %v", err)
+	}
+	if _, err := cacher.GetOAuth1Token(); !errors.Is(err, ErrTokenCacheNotFound) {
+		t.Errorf("oauth1 token should be deleted, got %v", err)
+	}
+	out.Auth, out.Cookie = "", ""
+	if err := client.apiGet(&out, "/weight-service/weight/first", nil); err != nil {
+		t.Fatal(err)
+	}
+	if out.Auth != "" || out.Cookie != "" {
+		t.Errorf("after logout: got %+v", out)
+	}
+
+	// The client can be reused for another account.
+	second := cachedTokens("second")
+	at, _ := second.GetAccessToken()
+	ot, _ := second.GetOAuth1Token()
+	if err := client.LoginWithTokens(ot, at); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.apiGet(&out, "/weight-service/weight/first", nil); err != nil {
+		t.Fatal(err)
+	}
+	if out.Auth != "Bearer second" {
+		t.Errorf("after second login: got %+v", out)
+	}
+}
+
+func TestLoggerRedaction(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	transport := &fakeTransport{fn: func(req *http.Request) (*http.Response, error) {
+		res := jsonResponse(req, http.StatusOK, `{"access_token": "secret-access", "refresh_token": "secret-refresh", "expires_in": 3600}`)
+		res.Header.Set("Set-Cookie", "SESSION=secret-cookie")
+		return res, nil
+	}}
+	client := NewClient(WithTransport(transport), WithDebugging(true, false), WithLogger(logger))
+	client.authenticate(&OAuth1Token{Token: "token"}, &AccessToken{
+		AccessToken: "secret-bearer",
+		TokenType:   "Bearer",
+		Expires:     time.Now().Add(time.Hour).UnixMilli(),
+	})
+	err := client.Request(context.Background(), http.MethodPost, "/sso/signin?ticket=secret-ticket", map[string]string{
+		"username": "me@example.com",
+		"password": "secret-password",
+	}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	for _, secret := range []string{"secret-access", "secret-refresh", "secret-cookie", "secret-bearer", "secret-ticket", "secret-password"} {
+		if strings.Contains(out, secret) {
+			t.Errorf("%s was logged:\n%s", secret, out)
+		}
+	}
+	for _, want := range []string{"Send", "Receive", "expires_in", rt.Redacted} {
+		if !strings.Contains(out, want) {
+			t.Errorf("%q missing from log:\n%s", want, out)
+		}
+	}
+}
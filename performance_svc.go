@@ -0,0 +1,201 @@
+package garmin
+
+import (
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+)
+
+type PerformanceService service
+
+type RaceDistance string
+
+const (
+	Race5K           RaceDistance = "5K"
+	Race10K          RaceDistance = "10K"
+	RaceHalfMarathon RaceDistance = "halfMarathon"
+	RaceMarathon     RaceDistance = "marathon"
+)
+
+// RacePredictions are predicted finish times in seconds.
+type RacePredictions struct {
+	UserID           int64        `json:"userId"`
+	CalendarDate     CalendarDate `json:"calendarDate"`
+	FromCalendarDate CalendarDate `json:"fromCalendarDate"`
+	ToCalendarDate   CalendarDate `json:"toCalendarDate"`
+	Time5K           int          `json:"time5K"`
+	Time10K          int          `json:"time10K"`
+	TimeHalfMarathon int          `json:"timeHalfMarathon"`
+	TimeMarathon     int          `json:"timeMarathon"`
+}
+
+// Time returns the predicted finish time, zero for unknown distances.
+func (rp *RacePredictions) Time(d RaceDistance) time.Duration {
+	var s int
+	switch d {
+	case Race5K:
+		s = rp.Time5K
+	case Race10K:
+		s = rp.Time10K
+	case RaceHalfMarathon:
+		s = rp.TimeHalfMarathon
+	case RaceMarathon:
+		s = rp.TimeMarathon
+	}
+	return time.Duration(s) * time.Second
+}
+
+// RacePredictions returns the latest race predictions of the user. The
+// display name of the user is looked up on the first call.
+func (ps *PerformanceService) RacePredictions() (*RacePredictions, error) {
+	// GET https://connect.garmin.com/metrics-service/metrics/racepredictions/latest/<displayName>
+	name, err := ps.c.displayName()
+	if err != nil {
+		return nil, err
+	}
+	var rp RacePredictions
+	p := "/metrics-service/metrics/racepredictions/latest/" + url.PathEscape(name)
+	return &rp, ps.c.apiGet(&rp, p, nil)
+}
+
+// RacePredictionHistory returns the race predictions of each day between
+// start and end. The display name of the user is looked up on the first call.
+func (ps *PerformanceService) RacePredictionHistory(start, end time.Time) ([]Stat[RacePredictions], error) {
+	// GET https://connect.garmin.com/metrics-service/metrics/racepredictions/daily/<displayName>?fromCalendarDate=2024-07-20&toCalendarDate=2024-08-16
+	name, err := ps.c.displayName()
+	if err != nil {
+		return nil, err
+	}
+	var res []RacePredictions
+	p := "/metrics-service/metrics/racepredictions/daily/" + url.PathEscape(name)
+	err = ps.c.apiGet(&res, p, url.Values{
+		"fromCalendarDate": []string{start.Format(time.DateOnly)},
+		"toCalendarDate":   []string{end.Format(time.DateOnly)},
+	})
+	if err != nil {
+		return nil, err
+	}
+	stats := make([]Stat[RacePredictions], len(res))
+	for i, rp := range res {
+		stats[i] = Stat[RacePredictions]{CalendarDate: rp.CalendarDate.String(), Values: rp}
+	}
+	return stats, nil
+}
+
+// EnduranceContributor is the share of the endurance score from a group of
+// activity types, in percent.
+type EnduranceContributor struct {
+	ActivityTypeID *int    `json:"activityTypeId"`
+	Group          *int    `json:"group"`
+	Contribution   float64 `json:"contribution"`
+}
+
+type EnduranceScore struct {
+	UserProfilePK                        int64                  `json:"userProfilePK"`
+	DeviceID                             int64                  `json:"deviceId"`
+	CalendarDate                         CalendarDate           `json:"calendarDate"`
+	OverallScore                         int                    `json:"overallScore"`
+	Classification                       int                    `json:"classification"`
+	FeedbackPhrase                       int                    `json:"feedbackPhrase"`
+	PrimaryTrainingDevice                bool                   `json:"primaryTrainingDevice"`
+	GaugeLowerLimit                      int                    `json:"gaugeLowerLimit"`
+	ClassificationLowerLimitIntermediate int                    `json:"classificationLowerLimitIntermediate"`
+	ClassificationLowerLimitTrained      int                    `json:"classificationLowerLimitTrained"`
+	ClassificationLowerLimitWellTrained  int                    `json:"classificationLowerLimitWellTrained"`
+	ClassificationLowerLimitExpert       int                    `json:"classificationLowerLimitExpert"`
+	ClassificationLowerLimitSuperior     int                    `json:"classificationLowerLimitSuperior"`
+	ClassificationLowerLimitElite        int                    `json:"classificationLowerLimitElite"`
+	GaugeUpperLimit                      int                    `json:"gaugeUpperLimit"`
+	Contributors                         []EnduranceContributor `json:"contributors"`
+}
+
+func (ps *PerformanceService) EnduranceScore(date time.Time) (*EnduranceScore, error) {
+	// GET https://connect.garmin.com/metrics-service/metrics/endurancescore?calendarDate=2024-08-16
+	var es EnduranceScore
+	return &es, ps.c.apiGet(&es, "/metrics-service/metrics/endurancescore", url.Values{
+		"calendarDate": []string{date.Format(time.DateOnly)},
+	})
+}
+
+type Aggregation string
+
+const (
+	AggregationDaily   Aggregation = "daily"
+	AggregationWeekly  Aggregation = "weekly"
+	AggregationMonthly Aggregation = "monthly"
+)
+
+type EnduranceScoreGroup struct {
+	GroupAverage int                    `json:"groupAverage"`
+	GroupMax     int                    `json:"groupMax"`
+	Contributors []EnduranceContributor `json:"enduranceContributorDTOList"`
+}
+
+// EnduranceScoreHistory returns the average endurance score, and its
+// contributors, of each day, week or month between start and end.
+func (ps *PerformanceService) EnduranceScoreHistory(start, end time.Time, aggregation Aggregation) ([]Stat[EnduranceScoreGroup], error) {
+	// GET https://connect.garmin.com/metrics-service/metrics/endurancescore/stats?startDate=2024-05-25&endDate=2024-08-16&aggregation=weekly
+	var res struct {
+		GroupMap map[string]EnduranceScoreGroup `json:"groupMap"`
+	}
+	err := ps.c.apiGet(&res, "/metrics-service/metrics/endurancescore/stats", url.Values{
+		"startDate":   []string{start.Format(time.DateOnly)},
+		"endDate":     []string{end.Format(time.DateOnly)},
+		"aggregation": []string{string(aggregation)},
+	})
+	if err != nil {
+		return nil, err
+	}
+	stats := make([]Stat[EnduranceScoreGroup], 0, len(res.GroupMap))
+	for date, g := range res.GroupMap {
+		stats = append(stats, Stat[EnduranceScoreGroup]{CalendarDate: date, Values: g})
+	}
+	slices.SortFunc(stats, func(a, b Stat[EnduranceScoreGroup]) int { return strings.Compare(a.CalendarDate, b.CalendarDate) })
+	return stats, nil
+}
+
+// HillScore combines hill strength and hill endurance.
+type HillScore struct {
+	UserProfilePK             int64        `json:"userProfilePK"`
+	DeviceID                  int64        `json:"deviceId"`
+	CalendarDate              CalendarDate `json:"calendarDate"`
+	StrengthScore             int          `json:"strengthScore"`
+	EnduranceScore            int          `json:"enduranceScore"`
+	HillScoreClassificationID int          `json:"hillScoreClassificationId"`
+	OverallScore              int          `json:"overallScore"`
+	HillScoreFeedbackPhraseID int          `json:"hillScoreFeedbackPhraseId"`
+	VO2Max                    float64      `json:"vo2Max"`
+	VO2MaxPreciseValue        float64      `json:"vo2MaxPreciseValue"`
+	PrimaryTrainingDevice     bool         `json:"primaryTrainingDevice"`
+}
+
+func (ps *PerformanceService) HillScore(date time.Time) (*HillScore, error) {
+	// GET https://connect.garmin.com/metrics-service/metrics/hillscore?calendarDate=2024-08-16
+	var hs HillScore
+	return &hs, ps.c.apiGet(&hs, "/metrics-service/metrics/hillscore", url.Values{
+		"calendarDate": []string{date.Format(time.DateOnly)},
+	})
+}
+
+// HillScoreHistory returns the hill score of each day between start and end
+// that has one.
+func (ps *PerformanceService) HillScoreHistory(start, end time.Time) ([]Stat[HillScore], error) {
+	// GET https://connect.garmin.com/metrics-service/metrics/hillscore/stats?startDate=2024-07-20&endDate=2024-08-16&aggregation=daily
+	var res struct {
+		HillScores []HillScore `json:"hillScoreDTOList"`
+	}
+	err := ps.c.apiGet(&res, "/metrics-service/metrics/hillscore/stats", url.Values{
+		"startDate":   []string{start.Format(time.DateOnly)},
+		"endDate":     []string{end.Format(time.DateOnly)},
+		"aggregation": []string{string(AggregationDaily)},
+	})
+	if err != nil {
+		return nil, err
+	}
+	stats := make([]Stat[HillScore], len(res.HillScores))
+	for i, hs := range res.HillScores {
+		stats[i] = Stat[HillScore]{CalendarDate: hs.CalendarDate.String(), Values: hs}
+	}
+	return stats, nil
+}
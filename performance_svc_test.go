@@ -0,0 +1,54 @@
+package garmin
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestPerformance(t *testing.T) {
+	transport := &fakeTransport{fn: func(req *http.Request) (*http.Response, error) {
+		switch req.URL.Path {
+		case "/userprofile-service/userprofile/userProfileBase":
+			return jsonResponse(req, http.StatusOK, `{"displayName": "runner"}`), nil
+		case "/metrics-service/metrics/racepredictions/daily/runner":
+			if req.URL.Query().Get("fromCalendarDate") != "2024-08-15" {
+				break
+			}
+			return jsonResponse(req, http.StatusOK, `[
+				{"userId": 1, "calendarDate": "2024-08-15", "time5K": 1320, "time10K": 2760, "timeHalfMarathon": 6180, "timeMarathon": 13200},
+				{"userId": 1, "calendarDate": "2024-08-16", "time5K": 1310, "time10K": 2740, "timeHalfMarathon": 6150, "timeMarathon": 13150}
+			]`), nil
+		case "/metrics-service/metrics/endurancescore/stats":
+			return jsonResponse(req, http.StatusOK, `{"groupMap": {
+				"2024-08-12": {"groupAverage": 6500, "groupMax": 6600, "enduranceContributorDTOList": [{"activityTypeId": 1, "contribution": 70.5}, {"group": 8, "contribution": 29.5}]},
+				"2024-08-05": {"groupAverage": 6400, "groupMax": 6450, "enduranceContributorDTOList": []}
+			}}`), nil
+		}
+		return jsonResponse(req, http.StatusNotFound, `{}`), nil
+	}}
+	api := NewAPI(NewClient(WithTransport(transport)))
+	start, end := time.Date(2024, 8, 15, 0, 0, 0, 0, time.UTC), time.Date(2024, 8, 16, 0, 0, 0, 0, time.UTC)
+
+	predictions, err := api.Performance.RacePredictionHistory(start, end)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(predictions) != 2 || predictions[1].CalendarDate != "2024-08-16" {
+		t.Fatalf("predictions: %+v", predictions)
+	}
+	if d := predictions[1].Values.Time(RaceHalfMarathon); d != time.Hour+42*time.Minute+30*time.Second {
+		t.Errorf("half marathon: %s", d)
+	}
+
+	endurance, err := api.Performance.EnduranceScoreHistory(start, end, AggregationWeekly)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(endurance) != 2 || endurance[0].CalendarDate != "2024-08-05" || endurance[1].Values.GroupAverage != 6500 {
+		t.Fatalf("endurance: %+v", endurance)
+	}
+	if c := endurance[1].Values.Contributors; len(c) != 2 || *c[0].ActivityTypeID != 1 || *c[1].Group != 8 {
+		t.Errorf("contributors: %+v", c)
+	}
+}
@@ -0,0 +1,288 @@
+package garmin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	ErrAccountExists   = errors.New("account already exists in pool")
+	ErrAccountNotFound = errors.New("account not found in pool")
+	ErrNoCredentials   = errors.New("account has no password and no cached tokens")
+)
+
+// Account is one login managed by an AccountPool.
+type Account struct {
+	Name     string
+	Email    string
+	Password string
+	// Cacher holds the account's tokens. When nil the pool's NewCacher is
+	// used, or an InMemTokenCacher if that is nil too.
+	Cacher TokenCacher
+	// RateLimit is the minimum time between two requests on average, and
+	// Burst the number of requests that may be sent at once before the limit
+	// kicks in. A zero RateLimit disables rate limiting.
+	RateLimit time.Duration
+	Burst     int
+}
+
+// AccountPool manages many accounts, each with its own Client, cookie jar,
+// token cache and rate limit. Accounts log in lazily the first time they are
+// used; accounts without a password are resumed from their token cache.
+type AccountPool struct {
+	// NewCacher creates the token cache for accounts that don't have one.
+	NewCacher func(name string) TokenCacher
+	// ClientOpts returns extra options for one account's client. Use it for
+	// options that carry state, such as transports, which must not be shared
+	// between clients.
+	ClientOpts func(name string) []ClientOpt
+	// Parallelism limits how many accounts Each works on at the same time. Zero
+	// means no limit.
+	Parallelism int
+
+	opts     []ClientOpt
+	mu       sync.RWMutex
+	accounts map[string]*poolAccount
+}
+
+type poolAccount struct {
+	Account
+	mu     sync.Mutex
+	client *Client
+	api    *API
+}
+
+// NewAccountPool creates a pool whose clients are all built with opts. The
+// options are applied once per account, so they must not share state between
+// clients; WithTransport and WithDebugging belong in ClientOpts instead.
+func NewAccountPool(opts ...ClientOpt) *AccountPool {
+	return &AccountPool{
+		opts:     opts,
+		accounts: make(map[string]*poolAccount),
+	}
+}
+
+// PrefixedFileCachers returns a NewCacher function that stores every account's
+// tokens in dir, with the account name as file prefix.
+func PrefixedFileCachers(dir string) func(name string) TokenCacher {
+	return func(name string) TokenCacher {
+		c := NewFileTokenCacher(filepath.Clean(dir))
+		c.Prefix = name + "_"
+		return c
+	}
+}
+
+// Add registers an account. Nothing is sent to Garmin until the account is
+// used.
+func (p *AccountPool) Add(acc Account) error {
+	if len(acc.Name) == 0 {
+		return errors.New("account has no name")
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if _, ok := p.accounts[acc.Name]; ok {
+		return fmt.Errorf("%w: %q", ErrAccountExists, acc.Name)
+	}
+	if acc.Cacher == nil {
+		if p.NewCacher != nil {
+			acc.Cacher = p.NewCacher(acc.Name)
+		} else {
+			acc.Cacher = new(InMemTokenCacher)
+		}
+	}
+	p.accounts[acc.Name] = &poolAccount{Account: acc}
+	return nil
+}
+
+// Remove drops an account from the pool. Its cached tokens are left alone.
+func (p *AccountPool) Remove(name string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.accounts, name)
+}
+
+// Names returns the account names in sorted order.
+func (p *AccountPool) Names() []string {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	names := make([]string, 0, len(p.accounts))
+	for name := range p.accounts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Client returns the account's client, logging in first if needed.
+func (p *AccountPool) Client(name string) (*Client, error) {
+	acc, err := p.account(name)
+	if err != nil {
+		return nil, err
+	}
+	return acc.login(p.clientOpts(name))
+}
+
+// API returns the account's API, logging in first if needed.
+func (p *AccountPool) API(name string) (*API, error) {
+	acc, err := p.account(name)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = acc.login(p.clientOpts(name)); err != nil {
+		return nil, err
+	}
+	return acc.api, nil
+}
+
+func (p *AccountPool) clientOpts(name string) []ClientOpt {
+	opts := slices.Clip(p.opts)
+	if p.ClientOpts != nil {
+		opts = append(opts, p.ClientOpts(name)...)
+	}
+	return opts
+}
+
+func (p *AccountPool) account(name string) (*poolAccount, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	acc, ok := p.accounts[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrAccountNotFound, name)
+	}
+	return acc, nil
+}
+
+func (pa *poolAccount) login(opts []ClientOpt) (*Client, error) {
+	pa.mu.Lock()
+	defer pa.mu.Unlock()
+	if pa.client != nil {
+		return pa.client, nil
+	}
+	if len(pa.Password) == 0 {
+		if _, err := pa.Cacher.GetOAuth1Token(); err != nil {
+			return nil, fmt.Errorf("%w: %q", ErrNoCredentials, pa.Name)
+		}
+	}
+	opts = append(opts, WithCacher(pa.Cacher))
+	if pa.RateLimit > 0 {
+		opts = append(opts, WithRateLimit(pa.RateLimit, pa.Burst))
+	}
+	client := NewClient(opts...)
+	var err error
+	if len(pa.Password) == 0 {
+		err = client.Resume()
+	} else {
+		err = client.Login(pa.Email, pa.Password)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("login %q: %w", pa.Name, err)
+	}
+	pa.client = client
+	pa.api = NewAPI(client)
+	return client, nil
+}
+
+// PoolError collects the errors of the accounts that failed in Each.
+type PoolError struct {
+	Errors map[string]error
+}
+
+func (pe *PoolError) Error() string {
+	names := make([]string, 0, len(pe.Errors))
+	for name := range pe.Errors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	msgs := make([]string, len(names))
+	for i, name := range names {
+		msgs[i] = fmt.Sprintf("%s: %v", name, pe.Errors[name])
+	}
+	return fmt.Sprintf("%d account(s) failed: %s", len(names), strings.Join(msgs, "; "))
+}
+
+func (pe *PoolError) Unwrap() []error {
+	errs := make([]error, 0, len(pe.Errors))
+	for _, err := range pe.Errors {
+		errs = append(errs, err)
+	}
+	return errs
+}
+
+// Each calls fn for every account in parallel. Accounts that haven't logged
+// in yet are logged in first. The returned error is a *PoolError holding the
+// error of every account that failed, or nil if all succeeded.
+func (p *AccountPool) Each(ctx context.Context, fn func(ctx context.Context, name string, api *API) error) error {
+	res := RunAll(ctx, p, func(ctx context.Context, name string, api *API) (struct{}, error) {
+		return struct{}{}, fn(ctx, name, api)
+	})
+	pe := PoolError{Errors: make(map[string]error)}
+	for _, r := range res {
+		if r.Err != nil {
+			pe.Errors[r.Account] = r.Err
+		}
+	}
+	if len(pe.Errors) == 0 {
+		return nil
+	}
+	return &pe
+}
+
+// AccountResult is the outcome of a call made on one account by RunAll.
+type AccountResult[T any] struct {
+	Account string
+	Value   T
+	Err     error
+}
+
+// RunAll calls fn with ctx for every account in the pool in parallel and
+// returns the results sorted by account name. Accounts not yet started when ctx is done
+// get ctx.Err() as their error.
+//
+//	res := garmin.RunAll(ctx, pool, func(ctx context.Context, name string, api *garmin.API) (*garmin.DailySleep, error) {
+//	    return api.Sleep.Daily(day, 60)
+//	})
+func RunAll[T any](ctx context.Context, p *AccountPool, fn func(ctx context.Context, name string, api *API) (T, error)) []AccountResult[T] {
+	names := p.Names()
+	res := make([]AccountResult[T], len(names))
+	limit := p.Parallelism
+	if limit <= 0 || limit > len(names) {
+		limit = len(names)
+	}
+	sem := make(chan struct{}, max(limit, 1))
+	var wg sync.WaitGroup
+	for i, name := range names {
+		res[i].Account = name
+		select {
+		case <-ctx.Done():
+			res[i].Err = ctx.Err()
+			continue
+		case sem <- struct{}{}:
+		}
+		wg.Add(1)
+		go func() {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			if err := ctx.Err(); err != nil {
+				res[i].Err = err
+				return
+			}
+			api, err := p.API(name)
+			if err != nil {
+				res[i].Err = err
+				return
+			}
+			res[i].Value, res[i].Err = fn(ctx, name, api)
+		}()
+	}
+	wg.Wait()
+	return res
+}
@@ -0,0 +1,196 @@
+package garmin
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func cachedTokens(name string) TokenCacher {
+	c := new(InMemTokenCacher)
+	_ = c.SaveOAuth1Token(&OAuth1Token{Token: name, TokenSecret: name})
+	_ = c.SaveAccessToken(&AccessToken{
+		AccessToken:         name,
+		TokenType:           "Bearer",
+		Expires:             time.Now().Add(time.Hour).UnixMilli(),
+		RefreshTokenExpires: time.Now().Add(2 * time.Hour).UnixMilli(),
+	})
+	return c
+}
+
+func TestAccountPool(t *testing.T) {
+	var calls atomic.Int32
+	fn := func(req *http.Request) (*http.Response, error) {
+		calls.Add(1)
+		if req.Header.Get(authHeader) == "Bearer broken" {
+			return jsonResponse(req, http.StatusInternalServerError, `{}`), nil
+		}
+		return jsonResponse(req, http.StatusOK, `{"weight": 80000}`), nil
+	}
+	pool := NewAccountPool(WithDomain("example.com"))
+	pool.ClientOpts = func(string) []ClientOpt {
+		return []ClientOpt{WithTransport(&fakeTransport{fn: fn})}
+	}
+	pool.Parallelism = 2
+	for _, acc := range []Account{
+		{Name: "alice", Cacher: cachedTokens("alice")},
+		{Name: "bob", Cacher: cachedTokens("bob"), RateLimit: time.Millisecond},
+		{Name: "carol", Cacher: cachedTokens("broken")},
+		{Name: "dave"}, // no password and nothing cached
+	} {
+		if err := pool.Add(acc); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := pool.Add(Account{Name: "alice"}); !errors.Is(err, ErrAccountExists) {
+		t.Errorf("duplicate account: want %v, got %v", ErrAccountExists, err)
+	}
+
+	res := RunAll(context.Background(), pool, func(_ context.Context, name string, api *API) (float64, error) {
+		w, err := api.Weight.First()
+		if err != nil {
+			return 0, err
+		}
+		return w.Weight, nil
+	})
+	if len(res) != 4 {
+		t.Fatalf("got %d results, want 4", len(res))
+	}
+	for _, r := range res[:2] {
+		if r.Err != nil || r.Value != 80000 {
+			t.Errorf("%s: got %v, %v", r.Account, r.Value, r.Err)
+		}
+	}
+	if res[2].Account != "carol" || res[2].Err == nil {
+		t.Errorf("carol should have failed: %+v", res[2])
+	}
+	if !errors.Is(res[3].Err, ErrNoCredentials) {
+		t.Errorf("dave: want %v, got %v", ErrNoCredentials, res[3].Err)
+	}
+	if n := calls.Load(); n != 3 {
+		t.Errorf("got %d requests, want 3", n)
+	}
+
+	type ctxKey struct{}
+	ctx := context.WithValue(context.Background(), ctxKey{}, "each")
+	err := pool.Each(ctx, func(ctx context.Context, name string, api *API) error {
+		if ctx.Value(ctxKey{}) != "each" {
+			t.Errorf("%s: fn did not get the context passed to Each", name)
+		}
+		return nil
+	})
+	var pe *PoolError
+	if !errors.As(err, &pe) {
+		t.Fatalf("want *PoolError, got %v", err)
+	}
+	if len(pe.Errors) != 1 || !errors.Is(pe.Errors["dave"], ErrNoCredentials) {
+		t.Errorf("unexpected errors: %v", pe)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	for _, r := range RunAll(ctx, pool, func(context.Context, string, *API) (int, error) { return 1, nil }) {
+		if !errors.Is(r.Err, context.Canceled) {
+			t.Errorf("%s: want %v, got %v", r.Account, context.Canceled, r.Err)
+		}
+	}
+}
+
+func TestRateLimitUsesClock(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := fakeClock(start)
+	c := NewClient(
+		WithClock(&clock),
+		WithRateLimit(time.Hour, 1),
+		WithTransport(&fakeTransport{fn: func(req *http.Request) (*http.Response, error) {
+			return jsonResponse(req, http.StatusOK, `{}`), nil
+		}}),
+	)
+	get := func() error {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		return c.Get(ctx, "https://example.com/", nil, nil)
+	}
+	if err := get(); err != nil {
+		t.Fatal(err)
+	}
+	// With the wall clock the second request would have to wait an hour.
+	clock = fakeClock(start.Add(time.Hour))
+	if err := get(); err != nil {
+		t.Errorf("request after the interval passed on the client clock: %v", err)
+	}
+}
+
+// virtualClock passes time only when something waits on it.
+type virtualClock struct {
+	mu  sync.Mutex
+	now time.Time
+	// block makes After never fire.
+	block bool
+}
+
+func (vc *virtualClock) Now() time.Time {
+	vc.mu.Lock()
+	defer vc.mu.Unlock()
+	return vc.now
+}
+
+func (vc *virtualClock) After(d time.Duration) <-chan time.Time {
+	vc.mu.Lock()
+	defer vc.mu.Unlock()
+	ch := make(chan time.Time, 1)
+	if !vc.block {
+		vc.now = vc.now.Add(d)
+		ch <- vc.now
+	}
+	return ch
+}
+
+func (vc *virtualClock) advance(d time.Duration) {
+	vc.mu.Lock()
+	defer vc.mu.Unlock()
+	vc.now = vc.now.Add(d)
+}
+
+func TestRateLimitWaitsOnClock(t *testing.T) {
+	clock := &virtualClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	c := NewClient(
+		WithClock(clock),
+		WithRateLimit(time.Hour, 1),
+		WithTransport(&fakeTransport{fn: func(req *http.Request) (*http.Response, error) {
+			return jsonResponse(req, http.StatusOK, `{}`), nil
+		}}),
+	)
+	get := func(timeout time.Duration) error {
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+		return c.Get(ctx, "https://example.com/", nil, nil)
+	}
+	start := clock.Now()
+	for range 3 {
+		if err := get(time.Second); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// The waits passed on the clock instead of in real time.
+	if waited := clock.Now().Sub(start); waited != 2*time.Hour {
+		t.Errorf("waited %v on the clock, want 2h", waited)
+	}
+
+	// A cancelled wait gives its token back: an hour later there is one for
+	// the next request.
+	clock.mu.Lock()
+	clock.block = true
+	clock.mu.Unlock()
+	if err := get(10 * time.Millisecond); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("want %v, got %v", context.DeadlineExceeded, err)
+	}
+	clock.advance(time.Hour)
+	if err := get(10 * time.Millisecond); err != nil {
+		t.Errorf("request after a cancelled wait: %v", err)
+	}
+}
@@ -0,0 +1,299 @@
+package garmin
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"unicode/utf8"
+)
+
+// ErrNotLoggedIn is returned when a session is exported from a client that
+// has no tokens.
+var ErrNotLoggedIn = errors.New("client is not logged in")
+
+const (
+	garthOAuth1File = "oauth1_token.json"
+	garthOAuth2File = "oauth2_token.json"
+)
+
+// garthOAuth1 mirrors garth's OAuth1Token dataclass.
+type garthOAuth1 struct {
+	OAuthToken             string  `json:"oauth_token"`
+	OAuthTokenSecret       string  `json:"oauth_token_secret"`
+	MFAToken               *string `json:"mfa_token"`
+	MFAExpirationTimestamp *string `json:"mfa_expiration_timestamp"`
+	Domain                 *string `json:"domain"`
+}
+
+func (o *garthOAuth1) fields() []pyField {
+	return []pyField{
+		{"oauth_token", o.OAuthToken},
+		{"oauth_token_secret", o.OAuthTokenSecret},
+		{"mfa_token", o.MFAToken},
+		{"mfa_expiration_timestamp", o.MFAExpirationTimestamp},
+		{"domain", o.Domain},
+	}
+}
+
+// garthOAuth2 mirrors garth's OAuth2Token dataclass. The expiry timestamps
+// are in seconds.
+type garthOAuth2 struct {
+	Scope                 string `json:"scope"`
+	JTI                   string `json:"jti"`
+	TokenType             string `json:"token_type"`
+	AccessToken           string `json:"access_token"`
+	RefreshToken          string `json:"refresh_token"`
+	ExpiresIn             int    `json:"expires_in"`
+	ExpiresAt             int64  `json:"expires_at"`
+	RefreshTokenExpiresIn int    `json:"refresh_token_expires_in"`
+	RefreshTokenExpiresAt int64  `json:"refresh_token_expires_at"`
+}
+
+func (o *garthOAuth2) fields() []pyField {
+	return []pyField{
+		{"scope", o.Scope},
+		{"jti", o.JTI},
+		{"token_type", o.TokenType},
+		{"access_token", o.AccessToken},
+		{"refresh_token", o.RefreshToken},
+		{"expires_in", o.ExpiresIn},
+		{"expires_at", o.ExpiresAt},
+		{"refresh_token_expires_in", o.RefreshTokenExpiresIn},
+		{"refresh_token_expires_at", o.RefreshTokenExpiresAt},
+	}
+}
+
+func toGarth(domain string, ot *OAuth1Token, at *AccessToken) (*garthOAuth1, *garthOAuth2) {
+	o1 := garthOAuth1{
+		OAuthToken:       ot.Token,
+		OAuthTokenSecret: ot.TokenSecret,
+		Domain:           &domain,
+	}
+	o2 := garthOAuth2{
+		Scope:                 at.Scope,
+		JTI:                   at.JTI,
+		TokenType:             at.TokenType,
+		AccessToken:           at.AccessToken,
+		RefreshToken:          at.RefreshToken,
+		ExpiresIn:             at.ExpiresIn,
+		ExpiresAt:             at.Expires / 1000,
+		RefreshTokenExpiresIn: at.RefreshTokenExpiresIn,
+		RefreshTokenExpiresAt: at.RefreshTokenExpires / 1000,
+	}
+	return &o1, &o2
+}
+
+func fromGarth(o1 *garthOAuth1, o2 *garthOAuth2) (*OAuth1Token, *AccessToken) {
+	ot := OAuth1Token{
+		Token:       o1.OAuthToken,
+		TokenSecret: o1.OAuthTokenSecret,
+	}
+	at := AccessToken{
+		Scope:                 o2.Scope,
+		JTI:                   o2.JTI,
+		TokenType:             o2.TokenType,
+		AccessToken:           o2.AccessToken,
+		RefreshToken:          o2.RefreshToken,
+		ExpiresIn:             o2.ExpiresIn,
+		Expires:               o2.ExpiresAt * 1000,
+		RefreshTokenExpiresIn: o2.RefreshTokenExpiresIn,
+		RefreshTokenExpires:   o2.RefreshTokenExpiresAt * 1000,
+	}
+	return &ot, &at
+}
+
+// tokens returns the tokens the client is currently using, or the cached ones
+// if it hasn't logged in.
+func (c *Client) tokens() (*OAuth1Token, *AccessToken, error) {
+	if c.auth != nil {
+		at, refresher := c.auth.tokens()
+		if otr, ok := refresher.(*oauth1TokenRefresher); ok {
+			return otr.token, at, nil
+		}
+	}
+	if c.Cacher != nil {
+		if ot, at, err := getCachedPair(c.Cacher); err == nil {
+			return ot, at, nil
+		}
+	}
+	return nil, nil, ErrNotLoggedIn
+}
+
+// ExportSession returns the client's tokens in the format of garth's
+// Client.dumps, a base64 encoded JSON array holding the OAuth1 and OAuth2
+// tokens. The result can be loaded by garth or by ImportSession.
+func (c *Client) ExportSession() (string, error) {
+	ot, at, err := c.tokens()
+	if err != nil {
+		return "", err
+	}
+	o1, o2 := toGarth(c.Domain, ot, at)
+	var buf bytes.Buffer
+	buf.WriteByte('[')
+	writePyObject(&buf, o1.fields(), "")
+	buf.WriteString(", ")
+	writePyObject(&buf, o2.fields(), "")
+	buf.WriteByte(']')
+	return base64.StdEncoding.EncodeToString(buf.Bytes()), nil
+}
+
+// ImportSession logs the client in with a session made by ExportSession or by
+// garth's Client.dumps. See LoginWithTokens for how expired tokens are
+// handled.
+func (c *Client) ImportSession(s string) error {
+	b, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return fmt.Errorf("decode session: %w", err)
+	}
+	var pair [2]json.RawMessage
+	if err = json.Unmarshal(b, &pair); err != nil {
+		return fmt.Errorf("decode session: %w", err)
+	}
+	var (
+		o1 garthOAuth1
+		o2 garthOAuth2
+	)
+	if err = json.Unmarshal(pair[0], &o1); err != nil {
+		return fmt.Errorf("decode oauth1 token: %w", err)
+	}
+	if err = json.Unmarshal(pair[1], &o2); err != nil {
+		return fmt.Errorf("decode oauth2 token: %w", err)
+	}
+	return c.importGarth(&o1, &o2)
+}
+
+// ExportSessionDir writes the session to dir the way garth's Client.dump
+// does, as oauth1_token.json and oauth2_token.json.
+func (c *Client) ExportSessionDir(dir string) error {
+	ot, at, err := c.tokens()
+	if err != nil {
+		return err
+	}
+	o1, o2 := toGarth(c.Domain, ot, at)
+	if err = os.MkdirAll(dir, 0700); err != nil {
+		return err
+	}
+	for name, fields := range map[string][]pyField{
+		garthOAuth1File: o1.fields(),
+		garthOAuth2File: o2.fields(),
+	} {
+		var buf bytes.Buffer
+		writePyObject(&buf, fields, "    ")
+		if err = os.WriteFile(filepath.Join(dir, name), buf.Bytes(), 0600); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ImportSessionDir logs the client in with a session saved by
+// ExportSessionDir or garth's Client.dump.
+func (c *Client) ImportSessionDir(dir string) error {
+	var (
+		o1 garthOAuth1
+		o2 garthOAuth2
+	)
+	for name, out := range map[string]any{garthOAuth1File: &o1, garthOAuth2File: &o2} {
+		b, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			return err
+		}
+		if err = json.Unmarshal(b, out); err != nil {
+			return fmt.Errorf("decode %s: %w", name, err)
+		}
+	}
+	return c.importGarth(&o1, &o2)
+}
+
+func (c *Client) importGarth(o1 *garthOAuth1, o2 *garthOAuth2) error {
+	if o1.Domain != nil && len(*o1.Domain) > 0 {
+		c.Domain = *o1.Domain
+	}
+	ot, at := fromGarth(o1, o2)
+	return c.LoginWithTokens(ot, at)
+}
+
+type pyField struct {
+	key   string
+	value any
+}
+
+// writePyObject writes fields the way Python's json module does: ", " and
+// ": " as separators, or one field per line when indent is set, and non-ASCII
+// characters escaped. Only the value types used by the garth tokens are
+// supported.
+func writePyObject(buf *bytes.Buffer, fields []pyField, indent string) {
+	buf.WriteByte('{')
+	for i, f := range fields {
+		if i > 0 {
+			buf.WriteByte(',')
+			if len(indent) == 0 {
+				buf.WriteByte(' ')
+			}
+		}
+		if len(indent) > 0 {
+			buf.WriteByte('\n')
+			buf.WriteString(indent)
+		}
+		writePyString(buf, f.key)
+		buf.WriteString(": ")
+		switch v := f.value.(type) {
+		case string:
+			writePyString(buf, v)
+		case *string:
+			if v == nil {
+				buf.WriteString("null")
+			} else {
+				writePyString(buf, *v)
+			}
+		case int:
+			buf.WriteString(strconv.Itoa(v))
+		case int64:
+			buf.WriteString(strconv.FormatInt(v, 10))
+		default:
+			panic(fmt.Sprintf("writePyObject: unsupported type %T", v))
+		}
+	}
+	if len(indent) > 0 && len(fields) > 0 {
+		buf.WriteByte('\n')
+	}
+	buf.WriteByte('}')
+}
+
+// writePyString quotes s like Python's json.dumps with ensure_ascii=True.
+func writePyString(buf *bytes.Buffer, s string) {
+	buf.WriteByte('"')
+	for _, r := range s {
+		switch {
+		case r == '"':
+			buf.WriteString(`\"`)
+		case r == '\\':
+			buf.WriteString(`\\`)
+		case r == '\n':
+			buf.WriteString(`\n`)
+		case r == '\r':
+			buf.WriteString(`\r`)
+		case r == '\t':
+			buf.WriteString(`\t`)
+		case r == '\b':
+			buf.WriteString(`\b`)
+		case r == '\f':
+			buf.WriteString(`\f`)
+		case r < 0x20:
+			fmt.Fprintf(buf, `\u%04x`, r)
+		case r > 0xFFFF:
+			r -= 0x10000
+			fmt.Fprintf(buf, `\u%04x\u%04x`, 0xD800+(r>>10), 0xDC00+(r&0x3FF))
+		case r >= utf8.RuneSelf:
+			fmt.Fprintf(buf, `\u%04x`, r)
+		default:
+			buf.WriteRune(r)
+		}
+	}
+	buf.WriteByte('"')
+}
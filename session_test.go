@@ -0,0 +1,67 @@
+package garmin
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// garthSession was made with garth's Client.dumps format:
+//
+//	base64.b64encode(json.dumps([asdict(oauth1), asdict(oauth2)]).encode())
+const garthSession = "W3sib2F1dGhfdG9rZW4iOiAidG9rIiwgIm9hdXRoX3Rva2VuX3NlY3JldCI6ICJzZWMiLCAibWZhX3Rva2VuIjogbnVsbCwgIm1mYV9leHBpcmF0aW9uX3RpbWVzdGFtcCI6IG51bGwsICJkb21haW4iOiAiZ2FybWluLmNvbSJ9LCB7InNjb3BlIjogIkNPTk5FQ1RfUkVBRCBDT05ORUNUX1dSSVRFIiwgImp0aSI6ICJqdGktXHUwMGU5IiwgInRva2VuX3R5cGUiOiAiQmVhcmVyIiwgImFjY2Vzc190b2tlbiI6ICJhY2MiLCAicmVmcmVzaF90b2tlbiI6ICJyZWYiLCAiZXhwaXJlc19pbiI6IDM2MDAsICJleHBpcmVzX2F0IjogNDEwMjQ0NDgwMCwgInJlZnJlc2hfdG9rZW5fZXhwaXJlc19pbiI6IDcyMDAsICJyZWZyZXNoX3Rva2VuX2V4cGlyZXNfYXQiOiA0MTAyNDQ4NDAwfV0="
+
+func TestSession(t *testing.T) {
+	cacher := new(InMemTokenCacher)
+	client := NewClient(WithCacher(cacher), WithDomain("example.com"))
+	if _, err := client.ExportSession(); err != ErrNotLoggedIn {
+		t.Errorf("want %v, got %v", ErrNotLoggedIn, err)
+	}
+	if err := client.ImportSession(garthSession); err != nil {
+		t.Fatal(err)
+	}
+	if client.Domain != "garmin.com" {
+		t.Errorf("domain should come from the session, got %q", client.Domain)
+	}
+	at, err := cacher.GetAccessToken()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if at.AccessToken != "acc" || at.JTI != "jti-é" || at.Expires != 4102444800000 {
+		t.Errorf("unexpected access token %+v", at)
+	}
+	s, err := client.ExportSession()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s != garthSession {
+		t.Errorf("export is not byte compatible with garth:\ngot  %s\nwant %s", s, garthSession)
+	}
+
+	dir := t.TempDir()
+	if err = client.ExportSessionDir(dir); err != nil {
+		t.Fatal(err)
+	}
+	b, err := os.ReadFile(filepath.Join(dir, "oauth1_token.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// json.dump(asdict(oauth1), f, indent=4)
+	exp := "{\n" +
+		`    "oauth_token": "tok",` + "\n" +
+		`    "oauth_token_secret": "sec",` + "\n" +
+		`    "mfa_token": null,` + "\n" +
+		`    "mfa_expiration_timestamp": null,` + "\n" +
+		`    "domain": "garmin.com"` + "\n" +
+		"}"
+	if string(b) != exp {
+		t.Errorf("oauth1_token.json:\ngot  %s\nwant %s", b, exp)
+	}
+	other := NewClient()
+	if err = other.ImportSessionDir(dir); err != nil {
+		t.Fatal(err)
+	}
+	if s, _ = other.ExportSession(); s != garthSession {
+		t.Errorf("directory round trip changed the session: %s", s)
+	}
+}
@@ -35,9 +35,9 @@ type WellnessEpochRespirationDataDTOList struct {
 
 // DurationLevel holds the value of the level and the start/end timestamp
 type DurationLevel struct {
-	StartGMT      string  `json:"startGMT"`
-	EndGMT        string  `json:"endGMT"`
-	ActivityLevel float64 `json:"activityLevel"`
+	StartGMT      GarminGMTTime `json:"startGMT"`
+	EndGMT        GarminGMTTime `json:"endGMT"`
+	ActivityLevel float64       `json:"activityLevel"`
 }
 
 type TimestampedValue struct {
@@ -46,58 +46,58 @@ type TimestampedValue struct {
 }
 
 type DailySleepDTO struct {
-	ID                            int64       `json:"id"`
-	UserProfilePK                 int         `json:"userProfilePK"`
-	CalendarDate                  string      `json:"calendarDate"`
-	SleepTimeSeconds              int         `json:"sleepTimeSeconds"`
-	NapTimeSeconds                int         `json:"napTimeSeconds"`
-	SleepWindowConfirmed          bool        `json:"sleepWindowConfirmed"`
-	SleepWindowConfirmationType   string      `json:"sleepWindowConfirmationType"`
-	SleepStartTimestampGMT        int64       `json:"sleepStartTimestampGMT"`
-	SleepEndTimestampGMT          int64       `json:"sleepEndTimestampGMT"`
-	SleepStartTimestampLocal      int64       `json:"sleepStartTimestampLocal"`
-	SleepEndTimestampLocal        int64       `json:"sleepEndTimestampLocal"`
-	AutoSleepStartTimestampGMT    any         `json:"autoSleepStartTimestampGMT"`
-	AutoSleepEndTimestampGMT      any         `json:"autoSleepEndTimestampGMT"`
-	SleepQualityTypePK            any         `json:"sleepQualityTypePK"`
-	SleepResultTypePK             any         `json:"sleepResultTypePK"`
-	UnmeasurableSleepSeconds      int         `json:"unmeasurableSleepSeconds"`
-	DeepSleepSeconds              int         `json:"deepSleepSeconds"`
-	LightSleepSeconds             int         `json:"lightSleepSeconds"`
-	RemSleepSeconds               int         `json:"remSleepSeconds"`
-	AwakeSleepSeconds             int         `json:"awakeSleepSeconds"`
-	DeviceRemCapable              bool        `json:"deviceRemCapable"`
-	Retro                         bool        `json:"retro"`
-	SleepFromDevice               bool        `json:"sleepFromDevice"`
-	AverageRespirationValue       float64     `json:"averageRespirationValue"`
-	LowestRespirationValue        float64     `json:"lowestRespirationValue"`
-	HighestRespirationValue       float64     `json:"highestRespirationValue"`
-	AwakeCount                    int         `json:"awakeCount"`
-	AvgSleepStress                float64     `json:"avgSleepStress"`
-	AgeGroup                      string      `json:"ageGroup"`
-	SleepScoreFeedback            string      `json:"sleepScoreFeedback"`
-	SleepScoreInsight             string      `json:"sleepScoreInsight"`
-	SleepScorePersonalizedInsight string      `json:"sleepScorePersonalizedInsight"`
-	SleepScores                   SleepScores `json:"sleepScores"`
-	SleepVersion                  int         `json:"sleepVersion"`
-	SleepNeed                     SleepNeed   `json:"sleepNeed"`
-	NextSleepNeed                 SleepNeed   `json:"nextSleepNeed"`
+	ID                            int64        `json:"id"`
+	UserProfilePK                 int          `json:"userProfilePK"`
+	CalendarDate                  CalendarDate `json:"calendarDate"`
+	SleepTimeSeconds              int          `json:"sleepTimeSeconds"`
+	NapTimeSeconds                int          `json:"napTimeSeconds"`
+	SleepWindowConfirmed          bool         `json:"sleepWindowConfirmed"`
+	SleepWindowConfirmationType   string       `json:"sleepWindowConfirmationType"`
+	SleepStartTimestampGMT        int64        `json:"sleepStartTimestampGMT"`
+	SleepEndTimestampGMT          int64        `json:"sleepEndTimestampGMT"`
+	SleepStartTimestampLocal      int64        `json:"sleepStartTimestampLocal"`
+	SleepEndTimestampLocal        int64        `json:"sleepEndTimestampLocal"`
+	AutoSleepStartTimestampGMT    any          `json:"autoSleepStartTimestampGMT"`
+	AutoSleepEndTimestampGMT      any          `json:"autoSleepEndTimestampGMT"`
+	SleepQualityTypePK            any          `json:"sleepQualityTypePK"`
+	SleepResultTypePK             any          `json:"sleepResultTypePK"`
+	UnmeasurableSleepSeconds      int          `json:"unmeasurableSleepSeconds"`
+	DeepSleepSeconds              int          `json:"deepSleepSeconds"`
+	LightSleepSeconds             int          `json:"lightSleepSeconds"`
+	RemSleepSeconds               int          `json:"remSleepSeconds"`
+	AwakeSleepSeconds             int          `json:"awakeSleepSeconds"`
+	DeviceRemCapable              bool         `json:"deviceRemCapable"`
+	Retro                         bool         `json:"retro"`
+	SleepFromDevice               bool         `json:"sleepFromDevice"`
+	AverageRespirationValue       float64      `json:"averageRespirationValue"`
+	LowestRespirationValue        float64      `json:"lowestRespirationValue"`
+	HighestRespirationValue       float64      `json:"highestRespirationValue"`
+	AwakeCount                    int          `json:"awakeCount"`
+	AvgSleepStress                float64      `json:"avgSleepStress"`
+	AgeGroup                      string       `json:"ageGroup"`
+	SleepScoreFeedback            string       `json:"sleepScoreFeedback"`
+	SleepScoreInsight             string       `json:"sleepScoreInsight"`
+	SleepScorePersonalizedInsight string       `json:"sleepScorePersonalizedInsight"`
+	SleepScores                   SleepScores  `json:"sleepScores"`
+	SleepVersion                  int          `json:"sleepVersion"`
+	SleepNeed                     SleepNeed    `json:"sleepNeed"`
+	NextSleepNeed                 SleepNeed    `json:"nextSleepNeed"`
 }
 
 type SleepNeed struct {
-	UserProfilePk            int    `json:"userProfilePk"`
-	CalendarDate             string `json:"calendarDate"`
-	DeviceID                 int64  `json:"deviceId"`
-	TimestampGmt             string `json:"timestampGmt"`
-	Baseline                 int    `json:"baseline"`
-	Actual                   int    `json:"actual"`
-	Feedback                 string `json:"feedback"`
-	TrainingFeedback         string `json:"trainingFeedback"`
-	SleepHistoryAdjustment   string `json:"sleepHistoryAdjustment"`
-	HrvAdjustment            string `json:"hrvAdjustment"`
-	NapAdjustment            string `json:"napAdjustment"`
-	DisplayedForTheDay       bool   `json:"displayedForTheDay"`
-	PreferredActivityTracker bool   `json:"preferredActivityTracker"`
+	UserProfilePk            int           `json:"userProfilePk"`
+	CalendarDate             CalendarDate  `json:"calendarDate"`
+	DeviceID                 int64         `json:"deviceId"`
+	TimestampGmt             GarminGMTTime `json:"timestampGmt"`
+	Baseline                 int           `json:"baseline"`
+	Actual                   int           `json:"actual"`
+	Feedback                 string        `json:"feedback"`
+	TrainingFeedback         string        `json:"trainingFeedback"`
+	SleepHistoryAdjustment   string        `json:"sleepHistoryAdjustment"`
+	HrvAdjustment            string        `json:"hrvAdjustment"`
+	NapAdjustment            string        `json:"napAdjustment"`
+	DisplayedForTheDay       bool          `json:"displayedForTheDay"`
+	PreferredActivityTracker bool          `json:"preferredActivityTracker"`
 }
 
 type SleepScores struct {
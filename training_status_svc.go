@@ -0,0 +1,297 @@
+package garmin
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+)
+
+type TrainingStatusService service
+
+// TrainingStatus is the training status shown on the device, without the
+// variant number Garmin adds to the feedback phrase.
+type TrainingStatus string
+
+const (
+	TrainingStatusNone         TrainingStatus = "NO_STATUS"
+	TrainingStatusPeaking      TrainingStatus = "PEAKING"
+	TrainingStatusProductive   TrainingStatus = "PRODUCTIVE"
+	TrainingStatusMaintaining  TrainingStatus = "MAINTAINING"
+	TrainingStatusRecovery     TrainingStatus = "RECOVERY"
+	TrainingStatusUnproductive TrainingStatus = "UNPRODUCTIVE"
+	TrainingStatusDetraining   TrainingStatus = "DETRAINING"
+	TrainingStatusOverreaching TrainingStatus = "OVERREACHING"
+	TrainingStatusStrained     TrainingStatus = "STRAINED"
+	TrainingStatusPaused       TrainingStatus = "PAUSED"
+)
+
+// TrainingStatusPhrase is Garmin's feedback phrase, like PRODUCTIVE_3.
+type TrainingStatusPhrase string
+
+func (p TrainingStatusPhrase) Status() TrainingStatus {
+	s := string(p)
+	if i := strings.LastIndexByte(s, '_'); i > 0 && strings.Trim(s[i+1:], "0123456789") == "" {
+		s = s[:i]
+	}
+	if len(s) == 0 {
+		return TrainingStatusNone
+	}
+	return TrainingStatus(s)
+}
+
+// LoadRatioBand is where the acute:chronic workload ratio falls.
+type LoadRatioBand string
+
+const (
+	LoadRatioLow      LoadRatioBand = "LOW"
+	LoadRatioOptimal  LoadRatioBand = "OPTIMAL"
+	LoadRatioHigh     LoadRatioBand = "HIGH"
+	LoadRatioVeryHigh LoadRatioBand = "VERY_HIGH"
+)
+
+type AcuteTrainingLoad struct {
+	ACWRPercent              int           `json:"acwrPercent"`
+	ACWRStatus               LoadRatioBand `json:"acwrStatus"`
+	ACWRStatusFeedback       string        `json:"acwrStatusFeedback"`
+	DailyTrainingLoadAcute   int           `json:"dailyTrainingLoadAcute"`
+	MaxTrainingLoadChronic   float64       `json:"maxTrainingLoadChronic"`
+	MinTrainingLoadChronic   float64       `json:"minTrainingLoadChronic"`
+	DailyTrainingLoadChronic int           `json:"dailyTrainingLoadChronic"`
+	DailyACWR                float64       `json:"dailyAcuteChronicWorkloadRatio"`
+}
+
+type TrainingStatusData struct {
+	CalendarDate                 CalendarDate         `json:"calendarDate"`
+	SinceDate                    *CalendarDate        `json:"sinceDate"`
+	WeeklyTrainingLoad           *int                 `json:"weeklyTrainingLoad"`
+	TrainingStatus               int                  `json:"trainingStatus"`
+	Timestamp                    UnixTS               `json:"timestamp"`
+	DeviceID                     int64                `json:"deviceId"`
+	LoadTunnelMin                *int                 `json:"loadTunnelMin"`
+	LoadTunnelMax                *int                 `json:"loadTunnelMax"`
+	LoadLevelTrend               *string              `json:"loadLevelTrend"`
+	Sport                        string               `json:"sport"`
+	SubSport                     string               `json:"subSport"`
+	FitnessTrendSport            string               `json:"fitnessTrendSport"`
+	FitnessTrend                 int                  `json:"fitnessTrend"`
+	TrainingStatusFeedbackPhrase TrainingStatusPhrase `json:"trainingStatusFeedbackPhrase"`
+	TrainingPaused               bool                 `json:"trainingPaused"`
+	AcuteTrainingLoad            AcuteTrainingLoad    `json:"acuteTrainingLoadDTO"`
+	PrimaryTrainingDevice        bool                 `json:"primaryTrainingDevice"`
+}
+
+func (tsd *TrainingStatusData) Status() TrainingStatus {
+	if tsd.TrainingPaused {
+		return TrainingStatusPaused
+	}
+	return tsd.TrainingStatusFeedbackPhrase.Status()
+}
+
+type RecordedDevice struct {
+	DeviceID   int64  `json:"deviceId"`
+	ImageURL   string `json:"imageURL"`
+	DeviceName string `json:"deviceName"`
+	Category   int    `json:"category"`
+}
+
+type TrainingStatusReport struct {
+	UserID int64 `json:"userId"`
+	// LatestTrainingStatusData is keyed by device id.
+	LatestTrainingStatusData map[string]TrainingStatusData `json:"latestTrainingStatusData"`
+	RecordedDevices          []RecordedDevice              `json:"recordedDevices"`
+	ShowSelector             bool                          `json:"showSelector"`
+	LastPrimarySyncDate      CalendarDate                  `json:"lastPrimarySyncDate"`
+}
+
+// Primary returns the data of the primary training device, or of any device
+// when none is marked primary.
+func (tsr *TrainingStatusReport) Primary() *TrainingStatusData {
+	return primaryDevice(tsr.LatestTrainingStatusData, func(d TrainingStatusData) bool { return d.PrimaryTrainingDevice })
+}
+
+// LoadFocusFeedback is Garmin's feedback on the training load focus, like
+// AEROBIC_HIGH_SHORTAGE or BALANCED.
+type LoadFocusFeedback string
+
+type TrainingLoadBalance struct {
+	CalendarDate                    CalendarDate      `json:"calendarDate"`
+	DeviceID                        int64             `json:"deviceId"`
+	MonthlyLoadAerobicLow           float64           `json:"monthlyLoadAerobicLow"`
+	MonthlyLoadAerobicHigh          float64           `json:"monthlyLoadAerobicHigh"`
+	MonthlyLoadAnaerobic            float64           `json:"monthlyLoadAnaerobic"`
+	MonthlyLoadAerobicLowTargetMin  int               `json:"monthlyLoadAerobicLowTargetMin"`
+	MonthlyLoadAerobicLowTargetMax  int               `json:"monthlyLoadAerobicLowTargetMax"`
+	MonthlyLoadAerobicHighTargetMin int               `json:"monthlyLoadAerobicHighTargetMin"`
+	MonthlyLoadAerobicHighTargetMax int               `json:"monthlyLoadAerobicHighTargetMax"`
+	MonthlyLoadAnaerobicTargetMin   int               `json:"monthlyLoadAnaerobicTargetMin"`
+	MonthlyLoadAnaerobicTargetMax   int               `json:"monthlyLoadAnaerobicTargetMax"`
+	TrainingBalanceFeedbackPhrase   LoadFocusFeedback `json:"trainingBalanceFeedbackPhrase"`
+	PrimaryTrainingDevice           bool              `json:"primaryTrainingDevice"`
+}
+
+type TrainingLoadBalanceReport struct {
+	UserID int64 `json:"userId"`
+	// MetricsTrainingLoadBalance is keyed by device id.
+	MetricsTrainingLoadBalance map[string]TrainingLoadBalance `json:"metricsTrainingLoadBalanceDTOMap"`
+	RecordedDevices            []RecordedDevice               `json:"recordedDevices"`
+}
+
+func (tlb *TrainingLoadBalanceReport) Primary() *TrainingLoadBalance {
+	return primaryDevice(tlb.MetricsTrainingLoadBalance, func(d TrainingLoadBalance) bool { return d.PrimaryTrainingDevice })
+}
+
+// MaxMet is a VO2max estimate.
+type MaxMet struct {
+	CalendarDate          CalendarDate `json:"calendarDate"`
+	VO2MaxPreciseValue    float64      `json:"vo2MaxPreciseValue"`
+	VO2MaxValue           float64      `json:"vo2MaxValue"`
+	FitnessAge            *int         `json:"fitnessAge"`
+	FitnessAgeDescription *string      `json:"fitnessAgeDescription"`
+	MaxMetCategory        int          `json:"maxMetCategory"`
+}
+
+type HeatAltitudeAcclimation struct {
+	CalendarDate              CalendarDate    `json:"calendarDate"`
+	AltitudeAcclimationDate   CalendarDate    `json:"altitudeAcclimationDate"`
+	HeatAcclimationDate       CalendarDate    `json:"heatAcclimationDate"`
+	AltitudeAcclimation       int             `json:"altitudeAcclimation"`
+	HeatAcclimationPercentage int             `json:"heatAcclimationPercentage"`
+	HeatTrend                 string          `json:"heatTrend"`
+	AltitudeTrend             string          `json:"altitudeTrend"`
+	CurrentAltitude           int             `json:"currentAltitude"`
+	AcclimationPercentage     int             `json:"acclimationPercentage"`
+	Timestamp                 GarminLocalTime `json:"altitudeAcclimationLocalTimestamp"`
+}
+
+// MaxMetDay has the VO2max of each sport for a day, nil when there was no
+// estimate for the sport.
+type MaxMetDay struct {
+	UserID                  int64                    `json:"userId"`
+	Generic                 *MaxMet                  `json:"generic"`
+	Cycling                 *MaxMet                  `json:"cycling"`
+	HeatAltitudeAcclimation *HeatAltitudeAcclimation `json:"heatAltitudeAcclimation"`
+}
+
+type MaxMetSport string
+
+const (
+	// MaxMetRunning is the generic VO2max, from running and walking.
+	MaxMetRunning MaxMetSport = "running"
+	MaxMetCycling MaxMetSport = "cycling"
+)
+
+type AggregatedTrainingStatus struct {
+	UserID                        int64                     `json:"userId"`
+	MostRecentVO2Max              MaxMetDay                 `json:"mostRecentVO2Max"`
+	MostRecentTrainingLoadBalance TrainingLoadBalanceReport `json:"mostRecentTrainingLoadBalance"`
+	MostRecentTrainingStatus      TrainingStatusReport      `json:"mostRecentTrainingStatus"`
+}
+
+// Aggregated returns the training status, load balance and VO2max as of date.
+func (tss *TrainingStatusService) Aggregated(date time.Time) (*AggregatedTrainingStatus, error) {
+	// GET https://connect.garmin.com/metrics-service/metrics/trainingstatus/aggregated/2024-08-16
+	var ats AggregatedTrainingStatus
+	p := fmt.Sprintf("/metrics-service/metrics/trainingstatus/aggregated/%s", date.Format(time.DateOnly))
+	return &ats, tss.c.apiGet(&ats, p, nil)
+}
+
+type trainingStatusRange struct {
+	UserID     int64                           `json:"userId"`
+	ReportData map[string][]TrainingStatusData `json:"reportData"`
+}
+
+// Daily returns the training status, with acute and chronic load, of the
+// primary training device for each day.
+func (tss *TrainingStatusService) Daily(start, end time.Time) ([]Stat[TrainingStatusData], error) {
+	// GET https://connect.garmin.com/metrics-service/metrics/trainingstatus/daily/2024-07-20/2024-08-16
+	var r trainingStatusRange
+	if err := tss.c.apiGet(&r, datepath("/metrics-service/metrics/trainingstatus/daily", start, end), nil); err != nil {
+		return nil, err
+	}
+	return primaryDailyStats(r.ReportData, func(d TrainingStatusData) (CalendarDate, bool) {
+		return d.CalendarDate, d.PrimaryTrainingDevice
+	}), nil
+}
+
+type trainingLoadBalanceRange struct {
+	UserID                     int64                            `json:"userId"`
+	MetricsTrainingLoadBalance map[string][]TrainingLoadBalance `json:"metricsTrainingLoadBalanceDTOMap"`
+}
+
+// LoadBalance returns the training load focus of the primary training device
+// for each day.
+func (tss *TrainingStatusService) LoadBalance(start, end time.Time) ([]Stat[TrainingLoadBalance], error) {
+	// GET https://connect.garmin.com/metrics-service/metrics/trainingloadbalance/daily/2024-07-20/2024-08-16
+	var r trainingLoadBalanceRange
+	if err := tss.c.apiGet(&r, datepath("/metrics-service/metrics/trainingloadbalance/daily", start, end), nil); err != nil {
+		return nil, err
+	}
+	return primaryDailyStats(r.MetricsTrainingLoadBalance, func(d TrainingLoadBalance) (CalendarDate, bool) {
+		return d.CalendarDate, d.PrimaryTrainingDevice
+	}), nil
+}
+
+// MaxMet returns the VO2max estimates of all sports between start and end.
+func (tss *TrainingStatusService) MaxMet(start, end time.Time) ([]MaxMetDay, error) {
+	// GET https://connect.garmin.com/metrics-service/metrics/maxmet/daily/2024-07-20/2024-08-16
+	var res []MaxMetDay
+	return res, tss.c.apiGet(&res, datepath("/metrics-service/metrics/maxmet/daily", start, end), nil)
+}
+
+// VO2Max returns the VO2max history of one sport, skipping days without an
+// estimate.
+func (tss *TrainingStatusService) VO2Max(sport MaxMetSport, start, end time.Time) ([]Stat[MaxMet], error) {
+	days, err := tss.MaxMet(start, end)
+	if err != nil {
+		return nil, err
+	}
+	var res []Stat[MaxMet]
+	for _, d := range days {
+		mm := d.Generic
+		if sport == MaxMetCycling {
+			mm = d.Cycling
+		}
+		if mm != nil {
+			res = append(res, Stat[MaxMet]{CalendarDate: mm.CalendarDate.String(), Values: *mm})
+		}
+	}
+	return res, nil
+}
+
+func primaryDevice[T any](byDevice map[string]T, primary func(T) bool) *T {
+	var res *T
+	for _, id := range slices.Sorted(maps.Keys(byDevice)) {
+		d := byDevice[id]
+		if primary(d) {
+			return &d
+		}
+		if res == nil {
+			res = &d
+		}
+	}
+	return res
+}
+
+// primaryDailyStats picks one value per day from the per device values,
+// preferring the primary training device.
+func primaryDailyStats[T any](byDevice map[string][]T, info func(T) (CalendarDate, bool)) []Stat[T] {
+	days := make(map[string]T)
+	primary := make(map[string]bool)
+	for _, id := range slices.Sorted(maps.Keys(byDevice)) {
+		for _, v := range byDevice[id] {
+			date, isPrimary := info(v)
+			day := date.String()
+			if _, ok := days[day]; !ok || (isPrimary && !primary[day]) {
+				days[day] = v
+				primary[day] = isPrimary
+			}
+		}
+	}
+	res := make([]Stat[T], 0, len(days))
+	for _, day := range slices.Sorted(maps.Keys(days)) {
+		res = append(res, Stat[T]{CalendarDate: day, Values: days[day]})
+	}
+	return res
+}
@@ -0,0 +1,71 @@
+package garmin
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestTrainingStatusPhrase(t *testing.T) {
+	for in, want := range map[TrainingStatusPhrase]TrainingStatus{
+		"PRODUCTIVE_3":  TrainingStatusProductive,
+		"MAINTAINING_1": TrainingStatusMaintaining,
+		"NO_STATUS":     TrainingStatusNone,
+		"OVERREACHING":  TrainingStatusOverreaching,
+		"":              TrainingStatusNone,
+	} {
+		if got := in.Status(); got != want {
+			t.Errorf("%q: got %s, want %s", in, got, want)
+		}
+	}
+}
+
+func TestTrainingStatusDaily(t *testing.T) {
+	transport := &fakeTransport{fn: func(req *http.Request) (*http.Response, error) {
+		switch req.URL.Path {
+		case "/metrics-service/metrics/trainingstatus/daily/2024-08-15/2024-08-16":
+			return jsonResponse(req, http.StatusOK, `{"userId": 1, "reportData": {
+				"2": [
+					{"calendarDate": "2024-08-16", "deviceId": 2, "trainingStatusFeedbackPhrase": "RECOVERY_1"},
+					{"calendarDate": "2024-08-15", "deviceId": 2, "trainingStatusFeedbackPhrase": "RECOVERY_1"}
+				],
+				"1": [
+					{"calendarDate": "2024-08-16", "deviceId": 1, "primaryTrainingDevice": true,
+					 "trainingStatusFeedbackPhrase": "PRODUCTIVE_2",
+					 "acuteTrainingLoadDTO": {"acwrStatus": "OPTIMAL", "dailyAcuteChronicWorkloadRatio": 1.1}}
+				]
+			}}`), nil
+		case "/metrics-service/metrics/maxmet/daily/2024-08-15/2024-08-16":
+			return jsonResponse(req, http.StatusOK, `[
+				{"userId": 1, "generic": {"calendarDate": "2024-08-15", "vo2MaxPreciseValue": 52.3, "vo2MaxValue": 52}, "cycling": null},
+				{"userId": 1, "generic": null, "cycling": {"calendarDate": "2024-08-16", "vo2MaxValue": 55}}
+			]`), nil
+		}
+		return jsonResponse(req, http.StatusNotFound, `{}`), nil
+	}}
+	api := NewAPI(NewClient(WithTransport(transport)))
+	start, end := time.Date(2024, 8, 15, 0, 0, 0, 0, time.UTC), time.Date(2024, 8, 16, 0, 0, 0, 0, time.UTC)
+
+	days, err := api.TrainingStatus.Daily(start, end)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(days) != 2 || days[0].CalendarDate != "2024-08-15" || days[1].CalendarDate != "2024-08-16" {
+		t.Fatalf("days: %+v", days)
+	}
+	if s := days[0].Values.Status(); s != TrainingStatusRecovery {
+		t.Errorf("only device: got %s", s)
+	}
+	primary := days[1].Values
+	if primary.DeviceID != 1 || primary.Status() != TrainingStatusProductive || primary.AcuteTrainingLoad.ACWRStatus != LoadRatioOptimal {
+		t.Errorf("primary device: %+v", primary)
+	}
+
+	vo2max, err := api.TrainingStatus.VO2Max(MaxMetRunning, start, end)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(vo2max) != 1 || vo2max[0].CalendarDate != "2024-08-15" || vo2max[0].Values.VO2MaxPreciseValue != 52.3 {
+		t.Errorf("vo2max: %+v", vo2max)
+	}
+}
@@ -0,0 +1,162 @@
+package units
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// System is a measurement system as named by Garmin in the user settings.
+type System string
+
+const (
+	Metric    System = "metric"
+	StatuteUS System = "statute_us"
+	StatuteUK System = "statute_uk"
+	Nautical  System = "nautical"
+)
+
+// ParseSystem maps Garmin's measurement system names onto a System, falling
+// back to Metric for anything it does not recognize.
+func ParseSystem(s string) System {
+	switch sys := System(strings.ToLower(s)); sys {
+	case Metric, StatuteUS, StatuteUK, Nautical:
+		return sys
+	case "statute":
+		return StatuteUS
+	default:
+		return Metric
+	}
+}
+
+func (s System) statute() bool { return s == StatuteUS || s == StatuteUK }
+
+// Formatter renders quantities in the units of one measurement system.
+type Formatter struct {
+	System System
+	// MinFraction and MaxFraction bound the number of fractional digits, the
+	// same way Garmin's own format settings do. Trailing zeros are trimmed down
+	// to MinFraction.
+	MinFraction int
+	MaxFraction int
+}
+
+// NewFormatter creates a Formatter for the system using two fractional digits.
+func NewFormatter(system System) Formatter {
+	return Formatter{System: system, MinFraction: 0, MaxFraction: 2}
+}
+
+// PaceUnit is the distance that paces are given per: a kilometer, a mile or a
+// nautical mile.
+func (f Formatter) PaceUnit() Distance {
+	switch {
+	case f.System.statute():
+		return Mile
+	case f.System == Nautical:
+		return NauticalMile
+	default:
+		return Kilometer
+	}
+}
+
+func (f Formatter) Distance(d Distance) string {
+	switch {
+	case f.System.statute():
+		return f.number(d.Miles()) + " mi"
+	case f.System == Nautical:
+		return f.number(d.NauticalMiles()) + " nmi"
+	default:
+		return f.number(d.Kilometers()) + " km"
+	}
+}
+
+func (f Formatter) Speed(s Speed) string {
+	switch {
+	case f.System.statute():
+		return f.number(s.MilesPerHour()) + " mph"
+	case f.System == Nautical:
+		return f.number(s.Knots()) + " kn"
+	default:
+		return f.number(s.KilometersPerHour()) + " km/h"
+	}
+}
+
+// Pace renders s as minutes per kilometer, mile or nautical mile, for example
+// "5:12 /km".
+func (f Formatter) Pace(s Speed) string {
+	var unit string
+	switch f.PaceUnit() {
+	case Mile:
+		unit = "mi"
+	case NauticalMile:
+		unit = "nmi"
+	default:
+		unit = "km"
+	}
+	return FormatPace(s.Pace(f.PaceUnit())) + " /" + unit
+}
+
+func (f Formatter) Mass(m Mass) string {
+	if f.System.statute() {
+		return f.number(m.Pounds()) + " lbs"
+	}
+	return f.number(m.Kilograms()) + " kg"
+}
+
+// Length renders body measurements as centimeters, or feet and inches.
+func (f Formatter) Length(l Length) string {
+	if !f.System.statute() {
+		return f.number(l.Centimeters()) + " cm"
+	}
+	// Round first, so that 71.996" is 6' 0" and not 5' 12".
+	scale := math.Pow10(max(f.MaxFraction, f.MinFraction))
+	total := math.Round(l.Inches()*scale) / scale
+	ft := math.Floor(total / 12)
+	return fmt.Sprintf("%.0f' %s\"", ft, f.number(total-ft*12))
+}
+
+func (f Formatter) Elevation(e Elevation) string {
+	if f.System.statute() || f.System == Nautical {
+		return f.number(e.Feet()) + " ft"
+	}
+	return f.number(e.Meters()) + " m"
+}
+
+// Temperature uses Fahrenheit only for StatuteUS.
+func (f Formatter) Temperature(t Temperature) string {
+	if f.System == StatuteUS {
+		return f.number(t.Fahrenheit()) + " °F"
+	}
+	return f.number(t.Celsius()) + " °C"
+}
+
+func (f Formatter) number(v float64) string {
+	digits := f.MaxFraction
+	if digits < f.MinFraction {
+		digits = f.MinFraction
+	}
+	s := strconv.FormatFloat(v, 'f', digits, 64)
+	if digits == 0 || !strings.Contains(s, ".") {
+		return s
+	}
+	minLen := strings.IndexByte(s, '.') + 1 + f.MinFraction
+	for len(s) > minLen && s[len(s)-1] == '0' {
+		s = s[:len(s)-1]
+	}
+	return strings.TrimSuffix(s, ".")
+}
+
+// FormatPace renders a duration as m:ss, or h:mm:ss when it is an hour or
+// longer.
+func FormatPace(d time.Duration) string {
+	d = d.Round(time.Second)
+	h := int(d / time.Hour)
+	m := int(d % time.Hour / time.Minute)
+	s := int(d % time.Minute / time.Second)
+	if h > 0 {
+		return fmt.Sprintf("%d:%02d:%02d", h, m, s)
+	}
+	return fmt.Sprintf("%d:%02d", m, s)
+}
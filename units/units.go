@@ -0,0 +1,123 @@
+// Package units holds typed physical quantities used by the Garmin API and
+// conversions between the metric, statute and nautical systems.
+//
+// Every quantity is a float64 stored in the unit Garmin uses on the wire so
+// that struct fields can be decoded directly from JSON, and the unit constants
+// work the same way as the ones in the time package:
+//
+//	d := 5 * units.Kilometer
+//	fmt.Println(d.Miles())
+package units
+
+import "time"
+
+// Distance in meters.
+type Distance float64
+
+const (
+	Millimeter   Distance = 0.001
+	Centimeter   Distance = 0.01
+	Meter        Distance = 1
+	Kilometer    Distance = 1000
+	Inch         Distance = 0.0254
+	Foot         Distance = 12 * Inch
+	Yard         Distance = 3 * Foot
+	Mile         Distance = 1760 * Yard
+	NauticalMile Distance = 1852
+)
+
+func (d Distance) Meters() float64        { return float64(d) }
+func (d Distance) Kilometers() float64    { return float64(d / Kilometer) }
+func (d Distance) Centimeters() float64   { return float64(d / Centimeter) }
+func (d Distance) Inches() float64        { return float64(d / Inch) }
+func (d Distance) Feet() float64          { return float64(d / Foot) }
+func (d Distance) Yards() float64         { return float64(d / Yard) }
+func (d Distance) Miles() float64         { return float64(d / Mile) }
+func (d Distance) NauticalMiles() float64 { return float64(d / NauticalMile) }
+
+// Length is a body measurement such as height, in centimeters.
+type Length float64
+
+const (
+	LengthCentimeter Length = 1
+	LengthMeter      Length = 100
+	LengthInch       Length = 2.54
+	LengthFoot       Length = 12 * LengthInch
+)
+
+func (l Length) Centimeters() float64 { return float64(l) }
+func (l Length) Meters() float64      { return float64(l / LengthMeter) }
+func (l Length) Inches() float64      { return float64(l / LengthInch) }
+func (l Length) Feet() float64        { return float64(l / LengthFoot) }
+func (l Length) Distance() Distance   { return Distance(l) * Centimeter }
+
+// Elevation above sea level or an elevation gain, in meters.
+type Elevation float64
+
+const (
+	ElevationMeter Elevation = 1
+	ElevationFoot  Elevation = Elevation(Foot)
+)
+
+func (e Elevation) Meters() float64 { return float64(e) }
+func (e Elevation) Feet() float64   { return float64(e / ElevationFoot) }
+
+// Speed in meters per second.
+type Speed float64
+
+const (
+	MeterPerSecond   Speed = 1
+	KilometerPerHour Speed = Speed(Kilometer) / 3600
+	MilePerHour      Speed = Speed(Mile) / 3600
+	Knot             Speed = Speed(NauticalMile) / 3600
+)
+
+func (s Speed) MetersPerSecond() float64   { return float64(s) }
+func (s Speed) KilometersPerHour() float64 { return float64(s / KilometerPerHour) }
+func (s Speed) MilesPerHour() float64      { return float64(s / MilePerHour) }
+func (s Speed) Knots() float64             { return float64(s / Knot) }
+
+// Pace returns the time it takes to cover d at speed s. A zero or negative
+// speed has a zero pace.
+func (s Speed) Pace(d Distance) time.Duration {
+	if s <= 0 {
+		return 0
+	}
+	return time.Duration(float64(d) / float64(s) * float64(time.Second))
+}
+
+// SpeedFromPace is the inverse of Speed.Pace.
+func SpeedFromPace(pace time.Duration, d Distance) Speed {
+	if pace <= 0 {
+		return 0
+	}
+	return Speed(float64(d) / pace.Seconds())
+}
+
+// Mass in grams.
+type Mass float64
+
+const (
+	Gram     Mass = 1
+	Kilogram Mass = 1000
+	Ounce    Mass = Pound / 16
+	Pound    Mass = 453.59237
+	Stone    Mass = 14 * Pound
+)
+
+func (m Mass) Grams() float64     { return float64(m) }
+func (m Mass) Kilograms() float64 { return float64(m / Kilogram) }
+func (m Mass) Ounces() float64    { return float64(m / Ounce) }
+func (m Mass) Pounds() float64    { return float64(m / Pound) }
+func (m Mass) Stones() float64    { return float64(m / Stone) }
+
+// Temperature in degrees Celsius.
+type Temperature float64
+
+func Celsius(c float64) Temperature    { return Temperature(c) }
+func Fahrenheit(f float64) Temperature { return Temperature((f - 32) * 5 / 9) }
+func Kelvin(k float64) Temperature     { return Temperature(k - 273.15) }
+
+func (t Temperature) Celsius() float64    { return float64(t) }
+func (t Temperature) Fahrenheit() float64 { return float64(t)*9/5 + 32 }
+func (t Temperature) Kelvin() float64     { return float64(t) + 273.15 }
@@ -0,0 +1,65 @@
+package units
+
+import (
+	"testing"
+	"time"
+)
+
+func TestConversions(t *testing.T) {
+	if got := (5 * Kilometer).Miles(); got < 3.1068 || got > 3.1069 {
+		t.Errorf("5 km in miles: got %v", got)
+	}
+	if got := (10 * Pound).Grams(); got != 4535.9237 {
+		t.Errorf("10 lbs in grams: got %v", got)
+	}
+	if got := Fahrenheit(212).Celsius(); got != 100 {
+		t.Errorf("212 F in C: got %v", got)
+	}
+	if got := (18 * Knot).KilometersPerHour(); got < 33.335 || got > 33.337 {
+		t.Errorf("18 kn in km/h: got %v", got)
+	}
+	// 12 km/h is a 5:00 /km pace.
+	if got := (12 * KilometerPerHour).Pace(Kilometer); got != 5*time.Minute {
+		t.Errorf("pace: got %v", got)
+	}
+}
+
+func TestFormatter(t *testing.T) {
+	speed := Speed(3.2) // 5:12.5 /km
+	for _, tt := range []struct {
+		system System
+		pace   string
+		dist   string
+	}{
+		{Metric, "5:13 /km", "10 km"},
+		{StatuteUS, "8:23 /mi", "6.21 mi"},
+		{Nautical, "9:39 /nmi", "5.4 nmi"},
+	} {
+		f := NewFormatter(tt.system)
+		if got := f.Pace(speed); got != tt.pace {
+			t.Errorf("%s pace: got %q, want %q", tt.system, got, tt.pace)
+		}
+		if got := f.Distance(10 * Kilometer); got != tt.dist {
+			t.Errorf("%s distance: got %q, want %q", tt.system, got, tt.dist)
+		}
+	}
+	for _, tt := range []struct {
+		cm   Length
+		want string
+	}{
+		{182.87, `6' 0"`},
+		{180, `5' 10.87"`},
+		{152.4, `5' 0"`},
+	} {
+		if got := NewFormatter(StatuteUS).Length(tt.cm); got != tt.want {
+			t.Errorf("length %v cm: got %q, want %q", float64(tt.cm), got, tt.want)
+		}
+	}
+	f := Formatter{System: Metric, MinFraction: 1, MaxFraction: 3}
+	if got := f.Mass(80 * Kilogram); got != "80.0 kg" {
+		t.Errorf("mass: got %q", got)
+	}
+	if got := ParseSystem("STATUTE_UK"); got != StatuteUK {
+		t.Errorf("parse system: got %q", got)
+	}
+}
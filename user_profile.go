@@ -2,8 +2,9 @@ package garmin
 
 import (
 	"fmt"
-	"net/http"
 	"net/url"
+
+	"github.com/jylitalo/go-garmin/units"
 )
 
 type UserProfileService service
@@ -43,6 +44,28 @@ func (up *UserProfileService) UserProfileBase() (*UserProfileBase, error) {
 	return &upb, up.c.apiGet(&upb, "/userprofile-service/userprofile/userProfileBase", nil)
 }
 
+// displayName returns the display name of the logged in user, it is only
+// looked up once per login.
+func (c *Client) displayName() (string, error) {
+	c.profile.Lock()
+	defer c.profile.Unlock()
+	if c.profile.displayName != "" {
+		return c.profile.displayName, nil
+	}
+	var upb UserProfileBase
+	if err := c.apiGet(&upb, "/userprofile-service/userprofile/userProfileBase", nil); err != nil {
+		return "", err
+	}
+	c.profile.displayName = upb.DisplayName
+	return upb.DisplayName, nil
+}
+
+func (c *Client) forgetProfile() {
+	c.profile.Lock()
+	c.profile.displayName = ""
+	c.profile.Unlock()
+}
+
 type UserSettings struct {
 	ID               int                        `json:"id"`
 	UserData         UserSettingsUserData       `json:"userData"`
@@ -131,6 +154,26 @@ type UserSettingsFormat struct {
 	DisplayFormat any    `json:"displayFormat"`
 }
 
+// MeasurementSystem returns the user's preferred measurement system.
+func (us *UserSettings) MeasurementSystem() units.System {
+	return units.ParseSystem(us.UserData.MeasurementSystem)
+}
+
+// Formatter returns a units.Formatter that renders values the way the user
+// has them configured in Garmin Connect.
+func (us *UserSettings) Formatter() units.Formatter {
+	return units.NewFormatter(us.MeasurementSystem())
+}
+
+// Formatter returns a units.Formatter for the measurement system using the
+// fraction digits from this format.
+func (usf *UserSettingsFormat) Formatter(system units.System) units.Formatter {
+	f := units.NewFormatter(system)
+	f.MinFraction = usf.MinFraction
+	f.MaxFraction = usf.MaxFraction
+	return f
+}
+
 func (up *UserProfileService) UserSettings() (*UserSettings, error) {
 	var us UserSettings
 	return &us, up.c.apiGet(&us, "/userprofile-service/userprofile/user-settings", nil)
@@ -296,17 +339,11 @@ func (up *UserProfileService) ProfileStatus(displayName string) (*ProfileStatus,
 func (up *UserProfileService) UpdateSocialProfile(displayName string, profile *SocialProfile) (*SocialProfile, error) {
 	// PUT https://connect.garmin.com/userprofile-service/socialProfile/<user_uuid>
 	var updated SocialProfile
-	status, err := up.c.api(
-		&updated,
-		"PUT",
-		fmt.Sprintf("/userprofile-service/socialProfile/%s", displayName),
-		nil,
-		profile,
-	)
-	if status != http.StatusOK {
-		return nil, fmt.Errorf("bad status code %d", status)
+	p := fmt.Sprintf("/userprofile-service/socialProfile/%s", displayName)
+	if err := up.c.apiSend(&updated, "PUT", p, profile); err != nil {
+		return nil, err
 	}
-	return &updated, err
+	return &updated, nil
 }
 
 // UserSettingsUpdate is the payload sent in order to update the user's
@@ -343,6 +380,17 @@ func (usu *UserSettingsUpdate) Gender(g string) *UserSettingsUpdate {
 // LeftHanded sets the request to update user settings to be left handed.
 func (usu *UserSettingsUpdate) LeftHanded() *UserSettingsUpdate { return usu.hand("LEFT") }
 
+// LactateThreshold sets the lactate threshold heart rate in bpm and speed.
+func (usu *UserSettingsUpdate) LactateThreshold(heartRate int, speed units.Speed) *UserSettingsUpdate {
+	if usu.UserData == nil {
+		usu.UserData = new(UserDataUpdate)
+	}
+	ts := ThresholdSpeedOf(speed)
+	usu.UserData.LactateThresholdHeartRate = &heartRate
+	usu.UserData.LactateThresholdSpeed = &ts
+	return usu
+}
+
 // RightHanded sets the request to update user settings to be right handed.
 func (usu *UserSettingsUpdate) RightHanded() *UserSettingsUpdate { return usu.hand("RIGHT") }
 
@@ -361,6 +409,9 @@ type UserDataUpdate struct {
 	// Height in centimeters.
 	Height     *float64 `json:"height,omitempty"`
 	Handedness *string  `json:"handedness,omitempty"`
+
+	LactateThresholdHeartRate *int            `json:"lactateThresholdHeartRate,omitempty"`
+	LactateThresholdSpeed     *ThresholdSpeed `json:"lactateThresholdSpeed,omitempty"`
 }
 
 // UpdateSettings will send a partial user settings object with only the fields
@@ -382,8 +433,7 @@ func (up *UserProfileService) UpdateSettings(usu *UserSettingsUpdate) error {
 	//
 	// Or to update both weight (g) and height (cm), use this payload:
 	//  {"userData":{"weight":79786.8328,"height":182.87999972202238}}
-	_, err := up.c.api(nil, "PUT", "/userprofile-service/userprofile/user-settings", nil, usu)
-	return err
+	return up.c.apiSend(nil, "PUT", "/userprofile-service/userprofile/user-settings", usu)
 }
 
 type PulseOxCapable struct {
@@ -158,3 +158,166 @@ func (uss *UserSummaryService) DailyIntensityMinutes(start, end time.Time) (s []
 func (uss *UserSummaryService) WeeklyIntensityMinutes(start, end time.Time) (s []IntensityMinutesStat, err error) {
 	return s, uss.c.apiGet(&s, datepath("/usersummary-service/stats/im/weekly", start, end), nil)
 }
+
+// DailyHydration is the water intake of a day in milliliters.
+type DailyHydration struct {
+	UserID                  int64            `json:"userId"`
+	CalendarDate            CalendarDate     `json:"calendarDate"`
+	ValueInML               float64          `json:"valueInML"`
+	GoalInML                float64          `json:"goalInML"`
+	DailyAverageInML        *float64         `json:"dailyAverageinML"`
+	LastEntryTimestampLocal *GarminLocalTime `json:"lastEntryTimestampLocal"`
+	SweatLossInML           *float64         `json:"sweatLossInML"`
+	ActivityIntakeInML      *float64         `json:"activityIntakeInML"`
+}
+
+func (uss *UserSummaryService) DailyHydration(date time.Time) (*DailyHydration, error) {
+	// GET https://connect.garmin.com/usersummary-service/usersummary/hydration/daily/2024-08-16
+	var h DailyHydration
+	p := fmt.Sprintf("/usersummary-service/usersummary/hydration/daily/%s", date.Format(time.DateOnly))
+	return &h, uss.c.apiGet(&h, p, nil)
+}
+
+// LogHydration adds ml of water drunk at the given time, negative values
+// remove water. The location of `at` decides the local date and time of the
+// entry. It returns the day's updated hydration.
+func (uss *UserSummaryService) LogHydration(ml float64, at time.Time) (*DailyHydration, error) {
+	// PUT https://connect.garmin.com/usersummary-service/usersummary/hydration/log
+	//
+	// {"calendarDate":"2024-08-16","timestampLocal":"2024-08-16T10:00:00.000","valueInML":250}
+	payload := struct {
+		CalendarDate   string  `json:"calendarDate"`
+		TimestampLocal string  `json:"timestampLocal"`
+		ValueInML      float64 `json:"valueInML"`
+	}{
+		CalendarDate:   at.Format(time.DateOnly),
+		TimestampLocal: at.Format("2006-01-02T15:04:05.000"),
+		ValueInML:      ml,
+	}
+	var h DailyHydration
+	if err := uss.c.apiSend(&h, "PUT", "/usersummary-service/usersummary/hydration/log", &payload); err != nil {
+		return nil, err
+	}
+	return &h, nil
+}
+
+// DailySummary is the consolidated summary of a day shown on the Garmin
+// Connect home page. Durations are in seconds, distances and floor heights in
+// meters and calories in kcal. Values are nil when the devices did not record
+// them.
+type DailySummary struct {
+	UserProfileID          int64           `json:"userProfileId"`
+	UserDailySummaryID     int64           `json:"userDailySummaryId"`
+	UUID                   string          `json:"uuid"`
+	CalendarDate           CalendarDate    `json:"calendarDate"`
+	Source                 string          `json:"source"`
+	WellnessStartTimeGMT   GarminGMTTime   `json:"wellnessStartTimeGmt"`
+	WellnessEndTimeGMT     GarminGMTTime   `json:"wellnessEndTimeGmt"`
+	WellnessStartTimeLocal GarminLocalTime `json:"wellnessStartTimeLocal"`
+	WellnessEndTimeLocal   GarminLocalTime `json:"wellnessEndTimeLocal"`
+	DurationInMilliseconds int64           `json:"durationInMilliseconds"`
+	LastSyncTimestampGMT   *GarminGMTTime  `json:"lastSyncTimestampGMT"`
+	IncludesWellnessData   bool            `json:"includesWellnessData"`
+	IncludesActivityData   bool            `json:"includesActivityData"`
+	PrivacyProtected       bool            `json:"privacyProtected"`
+
+	TotalSteps             int     `json:"totalSteps"`
+	DailyStepGoal          int     `json:"dailyStepGoal"`
+	TotalDistanceMeters    float64 `json:"totalDistanceMeters"`
+	WellnessDistanceMeters float64 `json:"wellnessDistanceMeters"`
+
+	TotalKilocalories           float64  `json:"totalKilocalories"`
+	ActiveKilocalories          float64  `json:"activeKilocalories"`
+	BMRKilocalories             float64  `json:"bmrKilocalories"`
+	WellnessKilocalories        float64  `json:"wellnessKilocalories"`
+	WellnessActiveKilocalories  float64  `json:"wellnessActiveKilocalories"`
+	BurnedKilocalories          *float64 `json:"burnedKilocalories"`
+	ConsumedKilocalories        *float64 `json:"consumedKilocalories"`
+	RemainingKilocalories       *float64 `json:"remainingKilocalories"`
+	NetRemainingKilocalories    *float64 `json:"netRemainingKilocalories"`
+	NetCalorieGoal              *float64 `json:"netCalorieGoal"`
+	RestingCaloriesFromActivity *float64 `json:"restingCaloriesFromActivity"`
+
+	FloorsAscended          float64 `json:"floorsAscended"`
+	FloorsDescended         float64 `json:"floorsDescended"`
+	FloorsAscendedInMeters  float64 `json:"floorsAscendedInMeters"`
+	FloorsDescendedInMeters float64 `json:"floorsDescendedInMeters"`
+	UserFloorsAscendedGoal  int     `json:"userFloorsAscendedGoal"`
+
+	ModerateIntensityMinutes int `json:"moderateIntensityMinutes"`
+	VigorousIntensityMinutes int `json:"vigorousIntensityMinutes"`
+	IntensityMinutesGoal     int `json:"intensityMinutesGoal"`
+
+	HighlyActiveSeconds int `json:"highlyActiveSeconds"`
+	ActiveSeconds       int `json:"activeSeconds"`
+	SedentarySeconds    int `json:"sedentarySeconds"`
+	SleepingSeconds     int `json:"sleepingSeconds"`
+
+	MinHeartRate                     *int `json:"minHeartRate"`
+	MaxHeartRate                     *int `json:"maxHeartRate"`
+	RestingHeartRate                 *int `json:"restingHeartRate"`
+	LastSevenDaysAvgRestingHeartRate *int `json:"lastSevenDaysAvgRestingHeartRate"`
+	MinAvgHeartRate                  *int `json:"minAvgHeartRate"`
+	MaxAvgHeartRate                  *int `json:"maxAvgHeartRate"`
+	AbnormalHeartRateAlertsCount     *int `json:"abnormalHeartRateAlertsCount"`
+
+	AverageStressLevel            *int     `json:"averageStressLevel"`
+	MaxStressLevel                *int     `json:"maxStressLevel"`
+	StressQualifier               string   `json:"stressQualifier"`
+	StressDuration                *int     `json:"stressDuration"`
+	RestStressDuration            *int     `json:"restStressDuration"`
+	ActivityStressDuration        *int     `json:"activityStressDuration"`
+	UncategorizedStressDuration   *int     `json:"uncategorizedStressDuration"`
+	TotalStressDuration           *int     `json:"totalStressDuration"`
+	LowStressDuration             *int     `json:"lowStressDuration"`
+	MediumStressDuration          *int     `json:"mediumStressDuration"`
+	HighStressDuration            *int     `json:"highStressDuration"`
+	StressPercentage              *float64 `json:"stressPercentage"`
+	RestStressPercentage          *float64 `json:"restStressPercentage"`
+	ActivityStressPercentage      *float64 `json:"activityStressPercentage"`
+	UncategorizedStressPercentage *float64 `json:"uncategorizedStressPercentage"`
+	LowStressPercentage           *float64 `json:"lowStressPercentage"`
+	MediumStressPercentage        *float64 `json:"mediumStressPercentage"`
+	HighStressPercentage          *float64 `json:"highStressPercentage"`
+	MeasurableAwakeDuration       *int     `json:"measurableAwakeDuration"`
+	MeasurableAsleepDuration      *int     `json:"measurableAsleepDuration"`
+
+	BodyBatteryChargedValue    *int     `json:"bodyBatteryChargedValue"`
+	BodyBatteryDrainedValue    *int     `json:"bodyBatteryDrainedValue"`
+	BodyBatteryHighestValue    *int     `json:"bodyBatteryHighestValue"`
+	BodyBatteryLowestValue     *int     `json:"bodyBatteryLowestValue"`
+	BodyBatteryMostRecentValue *int     `json:"bodyBatteryMostRecentValue"`
+	BodyBatteryDuringSleep     *int     `json:"bodyBatteryDuringSleep"`
+	BodyBatteryAtWakeTime      *int     `json:"bodyBatteryAtWakeTime"`
+	BodyBatteryVersion         *float64 `json:"bodyBatteryVersion"`
+
+	AverageSpO2                *float64         `json:"averageSpo2"`
+	LowestSpO2                 *int             `json:"lowestSpo2"`
+	LatestSpO2                 *int             `json:"latestSpo2"`
+	LatestSpO2ReadingTimeGMT   *GarminGMTTime   `json:"latestSpo2ReadingTimeGmt"`
+	LatestSpO2ReadingTimeLocal *GarminLocalTime `json:"latestSpo2ReadingTimeLocal"`
+
+	AvgWakingRespirationValue *float64       `json:"avgWakingRespirationValue"`
+	HighestRespirationValue   *float64       `json:"highestRespirationValue"`
+	LowestRespirationValue    *float64       `json:"lowestRespirationValue"`
+	LatestRespirationValue    *float64       `json:"latestRespirationValue"`
+	LatestRespirationTimeGMT  *GarminGMTTime `json:"latestRespirationTimeGMT"`
+
+	AverageMonitoringEnvironmentAltitude *float64 `json:"averageMonitoringEnvironmentAltitude"`
+}
+
+// Daily returns the summary of the user's day. The display name of the user
+// is looked up on the first call.
+func (uss *UserSummaryService) Daily(date time.Time) (*DailySummary, error) {
+	// GET https://connect.garmin.com/usersummary-service/usersummary/daily/<displayName>?calendarDate=2024-08-16
+	name, err := uss.c.displayName()
+	if err != nil {
+		return nil, err
+	}
+	var ds DailySummary
+	return &ds, uss.c.apiGet(
+		&ds,
+		"/usersummary-service/usersummary/daily/"+url.PathEscape(name),
+		url.Values{"calendarDate": []string{date.Format(time.DateOnly)}},
+	)
+}
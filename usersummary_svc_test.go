@@ -0,0 +1,78 @@
+package garmin
+
+import (
+	"io"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestLogHydration(t *testing.T) {
+	var payload string
+	transport := &fakeTransport{fn: func(req *http.Request) (*http.Response, error) {
+		if req.Method != "PUT" || req.URL.Path != "/usersummary-service/usersummary/hydration/log" {
+			return jsonResponse(req, http.StatusNotFound, `{}`), nil
+		}
+		b, _ := io.ReadAll(req.Body)
+		payload = string(b)
+		return jsonResponse(req, http.StatusOK, `{"userId": 1, "calendarDate": "2024-08-16", "valueInML": 1250.0, "goalInML": 2500.0,
+			"lastEntryTimestampLocal": "2024-08-16T10:00:00.0"}`), nil
+	}}
+	api := NewAPI(NewClient(WithTransport(transport)))
+	loc := time.FixedZone("UTC-7", -7*60*60)
+
+	h, err := api.UserSummary.LogHydration(250, time.Date(2024, 8, 16, 22, 30, 0, 0, loc))
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := `{"calendarDate":"2024-08-16","timestampLocal":"2024-08-16T22:30:00.000","valueInML":250}` + "\n"
+	if payload != exp {
+		t.Errorf("got payload %s", payload)
+	}
+	if h.ValueInML != 1250 || h.CalendarDate.String() != "2024-08-16" {
+		t.Errorf("hydration: %+v", h)
+	}
+}
+
+func TestDailySummary(t *testing.T) {
+	profileRequests := 0
+	transport := &fakeTransport{fn: func(req *http.Request) (*http.Response, error) {
+		switch req.URL.Path {
+		case "/userprofile-service/userprofile/userProfileBase":
+			profileRequests++
+			return jsonResponse(req, http.StatusOK, `{"userProfilePk": 1234, "displayName": "runner"}`), nil
+		case "/usersummary-service/usersummary/daily/runner":
+			return jsonResponse(req, http.StatusOK, `{
+				"userProfileId": 1234, "calendarDate": "`+req.URL.Query().Get("calendarDate")+`",
+				"totalSteps": 12345, "dailyStepGoal": 10000, "totalDistanceMeters": 9876.0,
+				"totalKilocalories": 2600.0, "activeKilocalories": 800.0, "bmrKilocalories": 1800.0,
+				"floorsAscended": 12.0, "floorsDescended": 11.0, "moderateIntensityMinutes": 20, "vigorousIntensityMinutes": 35,
+				"minHeartRate": 45, "maxHeartRate": 171, "restingHeartRate": 48,
+				"averageStressLevel": 28, "stressQualifier": "BALANCED", "lowStressDuration": 18000, "highStressDuration": 1200,
+				"bodyBatteryHighestValue": 92, "bodyBatteryLowestValue": 18,
+				"averageSpo2": 95.0, "lowestSpo2": 88, "latestSpo2": null
+			}`), nil
+		}
+		return jsonResponse(req, http.StatusNotFound, `{}`), nil
+	}}
+	api := NewAPI(NewClient(WithTransport(transport)))
+
+	for _, day := range []int{15, 16} {
+		ds, err := api.UserSummary.Daily(time.Date(2024, 8, day, 0, 0, 0, 0, time.UTC))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ds.CalendarDate.Day() != day || ds.TotalSteps != 12345 || ds.BMRKilocalories != 1800 {
+			t.Errorf("summary: %+v", ds)
+		}
+		if *ds.RestingHeartRate != 48 || *ds.HighStressDuration != 1200 || *ds.BodyBatteryLowestValue != 18 {
+			t.Errorf("summary: %+v", ds)
+		}
+		if *ds.LowestSpO2 != 88 || ds.LatestSpO2 != nil {
+			t.Errorf("spo2: %v %v", ds.LowestSpO2, ds.LatestSpO2)
+		}
+	}
+	if profileRequests != 1 {
+		t.Errorf("display name was looked up %d times", profileRequests)
+	}
+}
@@ -1,10 +1,15 @@
 package garmin
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
-	"net/http"
+	"math"
 	"net/url"
 	"time"
+
+	"github.com/jylitalo/go-garmin/internal/fit"
+	"github.com/jylitalo/go-garmin/units"
 )
 
 type WeightService service
@@ -13,18 +18,37 @@ type WeightUnit string
 
 const (
 	WeightUnitLbs WeightUnit = "lbs"
+	WeightUnitKg  WeightUnit = "kg"
 )
 
-const (
-	gramsInAPound = 453.59237
-	poundsInAGram = 0.00220462262185
-)
+// ErrUnknownWeightUnit is returned for weight units other than WeightUnitLbs
+// and WeightUnitKg, including the empty unit.
+var ErrUnknownWeightUnit = errors.New("unknown weight unit")
+
+// Mass converts a weight given in this unit to a units.Mass.
+func (wu WeightUnit) Mass(weight float64) (units.Mass, error) {
+	switch wu {
+	case WeightUnitKg:
+		return units.Mass(weight) * units.Kilogram, nil
+	case WeightUnitLbs:
+		return units.Mass(weight) * units.Pound, nil
+	default:
+		return 0, fmt.Errorf("%w %q", ErrUnknownWeightUnit, string(wu))
+	}
+}
 
-func GramsToPounds(g float64) float64 { return g / gramsInAPound }
+func GramsToPounds(g float64) float64 { return units.Mass(g).Pounds() }
 
-func PoundsToGrams(lbs float64) float64 { return lbs / poundsInAGram }
+func PoundsToGrams(lbs float64) float64 { return (units.Mass(lbs) * units.Pound).Grams() }
 
+// UpdateWeight logs a weight measured now.
 func (ws *WeightService) UpdateWeight(weight float64, unit WeightUnit) error {
+	return ws.LogWeight(weight, unit, ws.c.Clock.Now())
+}
+
+// LogWeight logs a weight measured at the given time. The location of `at`
+// decides which local date the weigh-in is recorded on.
+func (ws *WeightService) LogWeight(weight float64, unit WeightUnit, at time.Time) error {
 	const dateFormat = "2006-01-02T15:04:05.99"
 	// POST /weight-service/user-weight
 	// Authorization: Bearer ...
@@ -36,35 +60,155 @@ func (ws *WeightService) UpdateWeight(weight float64, unit WeightUnit) error {
 	// NOTE:
 	// Might need "GET /gdprconsent-service/feature/UPLOAD?_=1723767405770"
 	// first, I'm not sure...
+	if _, err := unit.Mass(weight); err != nil {
+		return err
+	}
 	type WeightUpdateRequest struct {
 		Date  string  `json:"dateTimestamp"`
 		GMT   string  `json:"gmtTimestamp"`
 		Unit  string  `json:"unitKey"` // usually "lbs"
 		Value float64 `json:"value"`
 	}
-	now := ws.c.Clock.Now()
 	payload := WeightUpdateRequest{
-		Date:  now.Format(dateFormat),
-		GMT:   now.In(time.UTC).Format(dateFormat),
+		Date:  at.Format(dateFormat),
+		GMT:   at.In(time.UTC).Format(dateFormat),
 		Unit:  string(unit),
 		Value: weight,
 	}
-	status, err := ws.c.api(
-		nil, // output
-		"POST",
-		"/weight-service/user-weight",
-		nil, // url params
-		&payload,
+	return ws.c.apiSend(nil, "POST", "/weight-service/user-weight", &payload)
+}
+
+// BodyComposition is a full smart scale reading. Only Time, Weight and Unit
+// are required, the other fields are left out of the upload when nil.
+type BodyComposition struct {
+	// Time of the measurement. Garmin stores the reading in UTC and shows it
+	// in the user's time zone, so the location of Time does not matter as
+	// long as the instant is right.
+	Time time.Time
+	// Weight, MuscleMass, BoneMass and VisceralFatMass are in Unit, which is
+	// required.
+	Weight float64
+	Unit   WeightUnit
+	// BodyFat in percent.
+	BodyFat *float64
+	// BodyWater (hydration) in percent.
+	BodyWater       *float64
+	MuscleMass      *float64
+	BoneMass        *float64
+	VisceralFatMass *float64
+	// VisceralFat is the scale's visceral fat rating, usually 1-59.
+	VisceralFat *int
+	BMI         *float64
+	// MetabolicAge in years.
+	MetabolicAge   *int
+	PhysiqueRating *int
+	// BasalMet and ActiveMet are in kcal/day.
+	BasalMet  *float64
+	ActiveMet *float64
+}
+
+// FIT returns the reading encoded as a FIT weight scale file, which is what
+// the Garmin Connect web app uploads for body composition. Values that are
+// negative or too large for their FIT field are an error.
+func (bc *BodyComposition) FIT() ([]byte, error) {
+	if bc.Time.IsZero() {
+		return nil, errors.New("body composition has no timestamp")
+	}
+	if _, err := bc.Unit.Mass(bc.Weight); err != nil {
+		return nil, err
+	}
+	if bc.Weight <= 0 {
+		return nil, fmt.Errorf("invalid weight %v", bc.Weight)
+	}
+	var errs []error
+	// scaled converts v to a field value, the largest value of each type
+	// marks the field as not set and can't be used.
+	scaled := func(num byte, typ fit.BaseType, v float64) fit.Field {
+		f := fit.Field{Num: num, Type: typ, Value: typ.Invalid()}
+		r := math.Round(v)
+		if r < 0 || r >= float64(typ.Invalid()) || math.IsNaN(r) {
+			errs = append(errs, fmt.Errorf("weight scale field %d: value %v out of range", num, v))
+			return f
+		}
+		f.Value = uint64(r)
+		return f
+	}
+	kg := func(num byte, v float64) fit.Field {
+		m, _ := bc.Unit.Mass(v)
+		return scaled(num, fit.Uint16, m.Kilograms()*100)
+	}
+	opt := func(num byte, typ fit.BaseType, v *float64, scale float64) fit.Field {
+		if v == nil {
+			return fit.Field{Num: num, Type: typ, Value: typ.Invalid()}
+		}
+		return scaled(num, typ, *v*scale)
+	}
+	optMass := func(num byte, v *float64) fit.Field {
+		if v == nil {
+			return fit.Field{Num: num, Type: fit.Uint16, Value: fit.Uint16.Invalid()}
+		}
+		return kg(num, *v)
+	}
+	optInt := func(num byte, v *int) fit.Field {
+		if v == nil {
+			return fit.Field{Num: num, Type: fit.Uint8, Value: fit.Uint8.Invalid()}
+		}
+		return scaled(num, fit.Uint8, float64(*v))
+	}
+	const (
+		fileTypeWeight          = 9
+		manufacturerDevelopment = 255
+	)
+	ts := uint64(fit.Timestamp(bc.Time))
+	e := fit.NewEncoder()
+	e.Write(fit.MesgFileID,
+		fit.Field{Num: 0, Type: fit.Enum, Value: fileTypeWeight},
+		fit.Field{Num: 1, Type: fit.Uint16, Value: manufacturerDevelopment},
+		fit.Field{Num: 2, Type: fit.Uint16, Value: 0},
+		fit.Field{Num: 3, Type: fit.Uint32z, Value: 1},
+		fit.Field{Num: 4, Type: fit.Uint32, Value: ts},
 	)
+	e.Write(fit.MesgFileCreator,
+		fit.Field{Num: 0, Type: fit.Uint16, Value: 0},
+		fit.Field{Num: 1, Type: fit.Uint8, Value: 0},
+	)
+	e.Write(fit.MesgWeightScale,
+		fit.Field{Num: 253, Type: fit.Uint32, Value: ts},
+		kg(0, bc.Weight),
+		opt(1, fit.Uint16, bc.BodyFat, 100),
+		opt(2, fit.Uint16, bc.BodyWater, 100),
+		optMass(3, bc.VisceralFatMass),
+		optMass(4, bc.BoneMass),
+		optMass(5, bc.MuscleMass),
+		opt(7, fit.Uint16, bc.BasalMet, 4),
+		optInt(8, bc.PhysiqueRating),
+		opt(9, fit.Uint16, bc.ActiveMet, 4),
+		optInt(10, bc.MetabolicAge),
+		optInt(11, bc.VisceralFat),
+		opt(13, fit.Uint16, bc.BMI, 10),
+	)
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+	return e.Bytes(), nil
+}
+
+// LogBodyComposition uploads a smart scale reading. Garmin only accepts body
+// composition through FIT uploads, so this goes through the upload service
+// and not the weight service used by LogWeight.
+func (ws *WeightService) LogBodyComposition(bc *BodyComposition) error {
+	// POST /upload-service/upload
+	// Content-Type: multipart/form-data
+	file, err := bc.FIT()
 	if err != nil {
 		return err
 	}
-	switch status {
-	case http.StatusOK, http.StatusCreated, http.StatusNoContent:
-		return nil
-	default:
-		return fmt.Errorf("invalid status code %d", status)
-	}
+	return ws.c.upload(
+		nil, // output
+		"/upload-service/upload",
+		"body_composition.fit",
+		bytes.NewReader(file),
+	)
 }
 
 func (ws *WeightService) First() (*WeighIn, error) {
@@ -101,16 +245,7 @@ func (ws *WeightService) DeleteWeight(date time.Time, version int64) error {
 		date.Format(time.DateOnly),
 		version,
 	)
-	status, err := ws.c.api(nil, "DELETE", path, nil, nil)
-	if err != nil {
-		return err
-	}
-	switch status {
-	case http.StatusOK, http.StatusNoContent, http.StatusAccepted:
-		return nil
-	default:
-		return fmt.Errorf("invalid status code %d", status)
-	}
+	return ws.c.apiSend(nil, "DELETE", path, nil)
 }
 
 type DailyWeightSummary struct {
@@ -0,0 +1,182 @@
+package garmin
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jylitalo/go-garmin/internal/fit"
+)
+
+func ptr[T any](v T) *T { return &v }
+
+func TestBodyCompositionFIT(t *testing.T) {
+	at := time.Date(2024, 8, 16, 7, 30, 0, 0, time.UTC)
+	bc := BodyComposition{
+		Time:        at,
+		Weight:      80.25,
+		Unit:        WeightUnitKg,
+		BodyFat:     ptr(18.5),
+		BoneMass:    ptr(3.2),
+		VisceralFat: ptr(7),
+		BMI:         ptr(24.3),
+		BasalMet:    ptr(1750.0),
+	}
+	b, err := bc.FIT()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if crc := fit.CRC(b); crc != 0 {
+		t.Errorf("file crc: got %#x", crc)
+	}
+	msgs, err := fit.Decode(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(msgs) != 3 {
+		t.Fatalf("got %d messages", len(msgs))
+	}
+	ts := uint64(fit.Timestamp(at))
+	for _, tt := range []struct {
+		mesg   uint16
+		fields map[byte]uint64
+	}{
+		{fit.MesgFileID, map[byte]uint64{0: 9, 1: 255, 2: 0, 3: 1, 4: ts}},
+		{fit.MesgFileCreator, map[byte]uint64{0: 0, 1: 0}},
+		{fit.MesgWeightScale, map[byte]uint64{
+			253: ts,
+			0:   8025,   // kg * 100
+			1:   1850,   // % * 100
+			2:   0xFFFF, // not set
+			4:   320,    // kg * 100
+			5:   0xFFFF,
+			7:   7000, // kcal * 4
+			8:   0xFF,
+			11:  7,
+			13:  243, // * 10
+		}},
+	} {
+		i := slices.IndexFunc(msgs, func(m fit.Message) bool { return m.Num == tt.mesg })
+		if i < 0 {
+			t.Fatalf("no message %d", tt.mesg)
+		}
+		m := msgs[i]
+		for num, exp := range tt.fields {
+			if f, ok := m.Field(num); !ok || f.Value != exp {
+				t.Errorf("message %d field %d: got %+v, want %d", tt.mesg, num, f, exp)
+			}
+		}
+	}
+
+	bc.Unit = WeightUnitLbs
+	bc.Weight = 176.9
+	b, err = bc.FIT()
+	if err != nil {
+		t.Fatal(err)
+	}
+	msgs, _ = fit.Decode(b)
+	if f, _ := msgs[2].Field(0); f.Value != 8024 {
+		t.Errorf("weight in lbs: got %d", f.Value)
+	}
+
+	for name, bad := range map[string]func(*BodyComposition){
+		"no unit":       func(bc *BodyComposition) { bc.Unit = "" },
+		"negative":      func(bc *BodyComposition) { bc.Weight = -80 },
+		"too heavy":     func(bc *BodyComposition) { bc.Unit, bc.Weight = WeightUnitKg, 700 },
+		"negative fat":  func(bc *BodyComposition) { bc.BodyFat = ptr(-1.0) },
+		"visceral":      func(bc *BodyComposition) { bc.VisceralFat = ptr(300) },
+		"no time":       func(bc *BodyComposition) { bc.Time = time.Time{} },
+		"huge basalmet": func(bc *BodyComposition) { bc.BasalMet = ptr(20000.0) },
+	} {
+		c := bc
+		bad(&c)
+		if _, err := c.FIT(); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+	if _, err := (&BodyComposition{Time: at, Weight: 80}).FIT(); !errors.Is(err, ErrUnknownWeightUnit) {
+		t.Errorf("zero unit: got %v", err)
+	}
+}
+
+func TestLogBodyComposition(t *testing.T) {
+	var (
+		method, path string
+		filename     string
+		file         []byte
+	)
+	transport := &fakeTransport{fn: func(req *http.Request) (*http.Response, error) {
+		method, path = req.Method, req.URL.Path
+		mt, params, err := mime.ParseMediaType(req.Header.Get("Content-Type"))
+		if err != nil || mt != "multipart/form-data" {
+			return jsonResponse(req, http.StatusBadRequest, `{}`), nil
+		}
+		mr := multipart.NewReader(req.Body, params["boundary"])
+		part, err := mr.NextPart()
+		if err != nil {
+			return nil, err
+		}
+		if part.FormName() != "file" {
+			return jsonResponse(req, http.StatusBadRequest, `{}`), nil
+		}
+		filename = part.FileName()
+		file, _ = io.ReadAll(part)
+		return jsonResponse(req, http.StatusCreated, `{"detailedImportResult": {}}`), nil
+	}}
+	api := NewAPI(NewClient(WithTransport(transport)))
+	bc := BodyComposition{Time: time.Date(2024, 8, 16, 7, 30, 0, 0, time.UTC), Weight: 80, Unit: WeightUnitKg}
+
+	if err := api.Weight.LogBodyComposition(&bc); err != nil {
+		t.Fatal(err)
+	}
+	exp, _ := bc.FIT()
+	if method != "POST" || path != "/upload-service/upload" || filename != "body_composition.fit" || !bytes.Equal(file, exp) {
+		t.Errorf("got %s %s with %q (%d bytes)", method, path, filename, len(file))
+	}
+
+	bc.Unit = ""
+	method = ""
+	if err := api.Weight.LogBodyComposition(&bc); !errors.Is(err, ErrUnknownWeightUnit) || method != "" {
+		t.Errorf("got %v, request sent: %v", err, method != "")
+	}
+}
+
+func TestWeightUnit(t *testing.T) {
+	for unit, exp := range map[WeightUnit]float64{WeightUnitKg: 80, WeightUnitLbs: 36.287} {
+		m, err := unit.Mass(80)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if kg := m.Kilograms(); kg < exp-0.001 || kg > exp+0.001 {
+			t.Errorf("%s: got %v kg, want %v", unit, kg, exp)
+		}
+	}
+	for _, unit := range []WeightUnit{"", "st", "KG"} {
+		if _, err := unit.Mass(80); !errors.Is(err, ErrUnknownWeightUnit) {
+			t.Errorf("%q: got %v", unit, err)
+		}
+	}
+	api := NewAPI(NewClient())
+	if err := api.Weight.LogBodyComposition(&BodyComposition{Time: time.Date(2024, 8, 16, 7, 30, 0, 0, time.UTC), Weight: 80}); !errors.Is(err, ErrUnknownWeightUnit) {
+		t.Errorf("body composition without a unit: got %v", err)
+	}
+}
+
+func TestDeleteWeightError(t *testing.T) {
+	transport := &fakeTransport{fn: func(req *http.Request) (*http.Response, error) {
+		return jsonResponse(req, http.StatusConflict, `{"message": "stale version"}`), nil
+	}}
+	api := NewAPI(NewClient(WithTransport(transport)))
+	err := api.Weight.DeleteWeight(time.Date(2024, 8, 16, 0, 0, 0, 0, time.UTC), 1)
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusConflict || !strings.Contains(string(apiErr.Body), "stale version") {
+		t.Errorf("want *APIError with the response, got %v", err)
+	}
+}
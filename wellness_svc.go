@@ -1,6 +1,7 @@
 package garmin
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/url"
 	"time"
@@ -36,9 +37,11 @@ func (ws *WellnessService) DailyHeartRate(date time.Time) (hr *DailyHeartRate, e
 	)
 }
 
-func (ws *WellnessService) DailySleep(userUUID string, date time.Time) (*DailySleep, error) {
+// DailySleep returns the sleep of the night that ended on date. The path
+// takes the user's display name, see UserProfileBase.
+func (ws *WellnessService) DailySleep(displayName string, date time.Time) (*DailySleep, error) {
 	var sd DailySleep
-	p := fmt.Sprintf("/wellness-service/wellness/dailySleepData/%s", userUUID)
+	p := fmt.Sprintf("/wellness-service/wellness/dailySleepData/%s", url.PathEscape(displayName))
 	return &sd, ws.c.apiGet(&sd, p, url.Values{"date": []string{date.Format(time.DateOnly)}})
 }
 
@@ -89,6 +92,125 @@ func (w *WellnessService) BodyBatteryMessagingToday() (*BodyBatteryMessagingToda
 	return &bbm, w.c.apiGet(&bbm, "/wellness-service/wellness/bodyBattery/messagingToday", nil)
 }
 
+type TrainingReadinessService service
+
+// ReadinessLevel is the feedback level of a training readiness score.
+type ReadinessLevel string
+
+const (
+	ReadinessPoor     ReadinessLevel = "POOR"
+	ReadinessLow      ReadinessLevel = "LOW"
+	ReadinessModerate ReadinessLevel = "MODERATE"
+	ReadinessHigh     ReadinessLevel = "HIGH"
+	ReadinessPrime    ReadinessLevel = "PRIME"
+)
+
+// ReadinessContextMorning is the InputContext of the morning report, the
+// readiness computed after waking up.
+const ReadinessContextMorning = "AFTER_WAKEUP_RESET"
+
+// TrainingReadiness is a readiness score. Garmin computes one after waking up
+// and updates it after activities and during the day.
+type TrainingReadiness struct {
+	UserProfilePK  int64           `json:"userProfilePK"`
+	CalendarDate   CalendarDate    `json:"calendarDate"`
+	Timestamp      GarminGMTTime   `json:"timestamp"`
+	TimestampLocal GarminLocalTime `json:"timestampLocal"`
+	DeviceID       int64           `json:"deviceId"`
+	Level          ReadinessLevel  `json:"level"`
+	FeedbackLong   string          `json:"feedbackLong"`
+	FeedbackShort  string          `json:"feedbackShort"`
+	Score          int             `json:"score"`
+	InputContext   string          `json:"inputContext"`
+	ValidSleep     bool            `json:"validSleep"`
+
+	SleepScore               *int   `json:"sleepScore"`
+	SleepScoreFactorPercent  int    `json:"sleepScoreFactorPercent"`
+	SleepScoreFactorFeedback string `json:"sleepScoreFactorFeedback"`
+	// RecoveryTime is in minutes from Timestamp.
+	RecoveryTime                int    `json:"recoveryTime"`
+	RecoveryTimeFactorPercent   int    `json:"recoveryTimeFactorPercent"`
+	RecoveryTimeFactorFeedback  string `json:"recoveryTimeFactorFeedback"`
+	RecoveryTimeChangePhrase    string `json:"recoveryTimeChangePhrase"`
+	AcuteLoad                   int    `json:"acuteLoad"`
+	ACWRFactorPercent           int    `json:"acwrFactorPercent"`
+	ACWRFactorFeedback          string `json:"acwrFactorFeedback"`
+	HRVWeeklyAverage            *int   `json:"hrvWeeklyAverage"`
+	HRVFactorPercent            int    `json:"hrvFactorPercent"`
+	HRVFactorFeedback           string `json:"hrvFactorFeedback"`
+	StressHistoryFactorPercent  int    `json:"stressHistoryFactorPercent"`
+	StressHistoryFactorFeedback string `json:"stressHistoryFactorFeedback"`
+	SleepHistoryFactorPercent   int    `json:"sleepHistoryFactorPercent"`
+	SleepHistoryFactorFeedback  string `json:"sleepHistoryFactorFeedback"`
+	PrimaryActivityTracker      bool   `json:"primaryActivityTracker"`
+}
+
+// ReadinessFactor is one of the factors contributing to the score. Percent is
+// how much of its maximum contribution it gives.
+type ReadinessFactor struct {
+	Name     string
+	Percent  int
+	Feedback string
+}
+
+func (tr *TrainingReadiness) Factors() []ReadinessFactor {
+	return []ReadinessFactor{
+		{"sleep", tr.SleepScoreFactorPercent, tr.SleepScoreFactorFeedback},
+		{"recoveryTime", tr.RecoveryTimeFactorPercent, tr.RecoveryTimeFactorFeedback},
+		{"acuteLoad", tr.ACWRFactorPercent, tr.ACWRFactorFeedback},
+		{"hrv", tr.HRVFactorPercent, tr.HRVFactorFeedback},
+		{"stressHistory", tr.StressHistoryFactorPercent, tr.StressHistoryFactorFeedback},
+		{"sleepHistory", tr.SleepHistoryFactorPercent, tr.SleepHistoryFactorFeedback},
+	}
+}
+
+// RecoveryTimeRemaining returns how much of the recovery time is left at now.
+func (tr *TrainingReadiness) RecoveryTimeRemaining(now time.Time) time.Duration {
+	done := time.Time(tr.Timestamp).Add(time.Duration(tr.RecoveryTime) * time.Minute)
+	return max(done.Sub(now), 0)
+}
+
+// Daily returns all readiness scores of the day.
+func (trs *TrainingReadinessService) Daily(date time.Time) (res []TrainingReadiness, err error) {
+	// GET https://connect.garmin.com/metrics-service/metrics/trainingreadiness/2024-08-16
+	p := fmt.Sprintf("/metrics-service/metrics/trainingreadiness/%s", date.Format(time.DateOnly))
+	return res, trs.c.apiGet(&res, p, nil)
+}
+
+// Morning returns the morning report of the day, or the earliest score when
+// there is none. It returns nil when there are no scores for the day.
+func (trs *TrainingReadinessService) Morning(date time.Time) (*TrainingReadiness, error) {
+	scores, err := trs.Daily(date)
+	if err != nil || len(scores) == 0 {
+		return nil, err
+	}
+	earliest := &scores[0]
+	for i := range scores {
+		if scores[i].InputContext == ReadinessContextMorning {
+			return &scores[i], nil
+		}
+		if time.Time(scores[i].Timestamp).Before(time.Time(earliest.Timestamp)) {
+			earliest = &scores[i]
+		}
+	}
+	return earliest, nil
+}
+
+// Latest returns the most recent score of the day, or nil when there is none.
+func (trs *TrainingReadinessService) Latest(date time.Time) (*TrainingReadiness, error) {
+	scores, err := trs.Daily(date)
+	if err != nil || len(scores) == 0 {
+		return nil, err
+	}
+	latest := &scores[0]
+	for i := range scores {
+		if time.Time(scores[i].Timestamp).After(time.Time(latest.Timestamp)) {
+			latest = &scores[i]
+		}
+	}
+	return latest, nil
+}
+
 type BodyBatteryEvent struct {
 	Event struct {
 		EventType              string `json:"eventType"`
@@ -206,3 +328,138 @@ func (w *WellnessService) HourlyIntensityMinutes(days int, end time.Time) (*Hour
 	p := fmt.Sprintf("/wellness-service/stats/hourly/im/%s/%d", end.Format(time.DateOnly), days)
 	return &him, w.c.apiGet(&him, p, nil)
 }
+
+// TimedValue is a sample of a daily time series.
+type TimedValue struct {
+	Time  time.Time
+	Value float64
+}
+
+// TimeSeries is a daily time series that Garmin sends as [epochMillis, value]
+// pairs. Pairs without a value are left out, and so are the negative values
+// Garmin uses for missing readings.
+type TimeSeries []TimedValue
+
+func (ts *TimeSeries) UnmarshalJSON(b []byte) error {
+	var pairs [][]*float64
+	if err := json.Unmarshal(b, &pairs); err != nil {
+		return err
+	}
+	*ts = (*ts)[:0]
+	for _, p := range pairs {
+		if len(p) < 2 || p[0] == nil || p[1] == nil || *p[1] < 0 {
+			continue
+		}
+		*ts = append(*ts, TimedValue{Time: time.UnixMilli(int64(*p[0])).UTC(), Value: *p[1]})
+	}
+	return nil
+}
+
+type SpO2Reading struct {
+	EpochTimestamp    GarminGMTTime `json:"epochTimestamp"`
+	Reading           int           `json:"spo2Reading"`
+	ReadingConfidence int           `json:"readingConfidence"`
+	ReadingType       string        `json:"readingType"`
+}
+
+// DailySpO2 has the pulse ox values of a day in percent. Values are nil when
+// the device took no readings.
+type DailySpO2 struct {
+	UserProfilePK              int64           `json:"userProfilePK"`
+	CalendarDate               CalendarDate    `json:"calendarDate"`
+	StartTimestampGMT          GarminGMTTime   `json:"startTimestampGMT"`
+	EndTimestampGMT            GarminGMTTime   `json:"endTimestampGMT"`
+	StartTimestampLocal        GarminLocalTime `json:"startTimestampLocal"`
+	EndTimestampLocal          GarminLocalTime `json:"endTimestampLocal"`
+	SleepStartTimestampGMT     GarminGMTTime   `json:"sleepStartTimestampGMT"`
+	SleepEndTimestampGMT       GarminGMTTime   `json:"sleepEndTimestampGMT"`
+	AverageSpO2                *float64        `json:"averageSpO2"`
+	LowestSpO2                 *int            `json:"lowestSpO2"`
+	LatestSpO2                 *int            `json:"latestSpO2"`
+	LatestSpO2TimestampGMT     GarminGMTTime   `json:"latestSpO2TimestampGMT"`
+	AvgSleepSpO2               *float64        `json:"avgSleepSpO2"`
+	LastSevenDaysAvgSpO2       *float64        `json:"lastSevenDaysAvgSpO2"`
+	AverageSleepSpO2Confidence *float64        `json:"avgSleepSpO2Confidence"`
+	HourlyAverages             TimeSeries      `json:"spO2HourlyAverages"`
+	SingleValues               []SpO2Reading   `json:"spO2SingleValues"`
+	ContinuousReadings         []SpO2Reading   `json:"continuousReadingDTOList"`
+}
+
+func (w *WellnessService) DailySpO2(date time.Time) (*DailySpO2, error) {
+	// GET https://connect.garmin.com/wellness-service/wellness/daily/spo2/2024-08-16
+	var spo2 DailySpO2
+	p := fmt.Sprintf("/wellness-service/wellness/daily/spo2/%s", date.Format(time.DateOnly))
+	return &spo2, w.c.apiGet(&spo2, p, nil)
+}
+
+// DailyRespiration has the breathing rate of a day in breaths per minute.
+type DailyRespiration struct {
+	UserProfilePK                    int64           `json:"userProfilePK"`
+	CalendarDate                     CalendarDate    `json:"calendarDate"`
+	StartTimestampGMT                GarminGMTTime   `json:"startTimestampGMT"`
+	EndTimestampGMT                  GarminGMTTime   `json:"endTimestampGMT"`
+	StartTimestampLocal              GarminLocalTime `json:"startTimestampLocal"`
+	EndTimestampLocal                GarminLocalTime `json:"endTimestampLocal"`
+	SleepStartTimestampGMT           GarminGMTTime   `json:"sleepStartTimestampGMT"`
+	SleepEndTimestampGMT             GarminGMTTime   `json:"sleepEndTimestampGMT"`
+	LowestRespirationValue           *float64        `json:"lowestRespirationValue"`
+	HighestRespirationValue          *float64        `json:"highestRespirationValue"`
+	AvgWakingRespirationValue        *float64        `json:"avgWakingRespirationValue"`
+	AvgSleepRespirationValue         *float64        `json:"avgSleepRespirationValue"`
+	AvgTomorrowSleepRespirationValue *float64        `json:"avgTomorrowSleepRespirationValue"`
+	RespirationValues                TimeSeries      `json:"respirationValuesArray"`
+}
+
+func (w *WellnessService) DailyRespiration(date time.Time) (*DailyRespiration, error) {
+	// GET https://connect.garmin.com/wellness-service/wellness/daily/respiration/2024-08-16
+	var resp DailyRespiration
+	p := fmt.Sprintf("/wellness-service/wellness/daily/respiration/%s", date.Format(time.DateOnly))
+	return &resp, w.c.apiGet(&resp, p, nil)
+}
+
+// FloorsInterval is the floors climbed in a 15 minute interval.
+type FloorsInterval struct {
+	Start     GarminGMTTime
+	End       GarminGMTTime
+	Ascended  int
+	Descended int
+}
+
+// UnmarshalJSON reads an interval from a [startGMT, endGMT, ascended,
+// descended] array.
+func (fi *FloorsInterval) UnmarshalJSON(b []byte) error {
+	var v [4]json.RawMessage
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+	for i, dst := range []any{&fi.Start, &fi.End, &fi.Ascended, &fi.Descended} {
+		if err := json.Unmarshal(v[i], dst); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type DailyFloors struct {
+	StartTimestampGMT   GarminGMTTime    `json:"startTimestampGMT"`
+	EndTimestampGMT     GarminGMTTime    `json:"endTimestampGMT"`
+	StartTimestampLocal GarminLocalTime  `json:"startTimestampLocal"`
+	EndTimestampLocal   GarminLocalTime  `json:"endTimestampLocal"`
+	Intervals           []FloorsInterval `json:"floorValuesArray"`
+}
+
+// Total returns the floors ascended and descended during the day.
+func (df *DailyFloors) Total() (ascended, descended int) {
+	for _, fi := range df.Intervals {
+		ascended += fi.Ascended
+		descended += fi.Descended
+	}
+	return ascended, descended
+}
+
+func (w *WellnessService) DailyFloors(date time.Time) (*DailyFloors, error) {
+	// GET https://connect.garmin.com/wellness-service/wellness/floorsChartData/daily/2024-08-16
+	var floors DailyFloors
+	p := fmt.Sprintf("/wellness-service/wellness/floorsChartData/daily/%s", date.Format(time.DateOnly))
+	return &floors, w.c.apiGet(&floors, p, nil)
+}
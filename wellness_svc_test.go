@@ -0,0 +1,104 @@
+package garmin
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestTrainingReadiness(t *testing.T) {
+	transport := &fakeTransport{fn: func(req *http.Request) (*http.Response, error) {
+		return jsonResponse(req, http.StatusOK, `[
+			{"calendarDate": "2024-08-16", "timestamp": "2024-08-16T12:00:00.0", "level": "MODERATE", "score": 55,
+			 "inputContext": "UPDATE_REALTIME_VARIABLES", "recoveryTime": 600, "acuteLoad": 500},
+			{"calendarDate": "2024-08-16", "timestamp": "2024-08-16T05:00:00.0", "level": "HIGH", "score": 78,
+			 "inputContext": "AFTER_WAKEUP_RESET", "recoveryTime": 120,
+			 "sleepScoreFactorPercent": 80, "sleepScoreFactorFeedback": "GOOD",
+			 "hrvFactorPercent": 100, "hrvFactorFeedback": "GOOD", "hrvWeeklyAverage": 48}
+		]`), nil
+	}}
+	api := NewAPI(NewClient(WithTransport(transport)))
+	date := time.Date(2024, 8, 16, 0, 0, 0, 0, time.UTC)
+
+	morning, err := api.TrainingReadiness.Morning(date)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if morning.Score != 78 || morning.Level != ReadinessHigh || *morning.HRVWeeklyAverage != 48 {
+		t.Errorf("morning: %+v", morning)
+	}
+	if f := morning.Factors(); f[0] != (ReadinessFactor{"sleep", 80, "GOOD"}) || f[3].Percent != 100 {
+		t.Errorf("factors: %+v", f)
+	}
+	if r := morning.RecoveryTimeRemaining(time.Date(2024, 8, 16, 6, 30, 0, 0, time.UTC)); r != 30*time.Minute {
+		t.Errorf("recovery time remaining: %s", r)
+	}
+	if r := morning.RecoveryTimeRemaining(time.Date(2024, 8, 16, 8, 0, 0, 0, time.UTC)); r != 0 {
+		t.Errorf("recovery time remaining after recovery: %s", r)
+	}
+
+	latest, err := api.TrainingReadiness.Latest(date)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if latest.Score != 55 || latest.AcuteLoad != 500 {
+		t.Errorf("latest: %+v", latest)
+	}
+}
+
+func TestDailyHealthMetrics(t *testing.T) {
+	transport := &fakeTransport{fn: func(req *http.Request) (*http.Response, error) {
+		switch req.URL.Path {
+		case "/wellness-service/wellness/daily/spo2/2024-08-16":
+			return jsonResponse(req, http.StatusOK, `{
+				"calendarDate": "2024-08-16", "averageSpO2": 93.0, "lowestSpO2": 84, "latestSpO2": 95, "avgSleepSpO2": 92.0,
+				"spO2HourlyAverages": [[1723766400000, 91], [1723770000000, null], [1723773600000, 94]],
+				"continuousReadingDTOList": [{"epochTimestamp": "2024-08-16T00:01:00.0", "spo2Reading": 90, "readingConfidence": 3, "readingType": "CONTINUOUS"}]
+			}`), nil
+		case "/wellness-service/wellness/daily/respiration/2024-08-16":
+			return jsonResponse(req, http.StatusOK, `{
+				"calendarDate": "2024-08-16", "lowestRespirationValue": 9.0, "highestRespirationValue": 21.0, "avgSleepRespirationValue": 13.0,
+				"respirationValuesArray": [[1723766400000, 12.0], [1723766520000, -1.0], [1723766640000, 13.0]]
+			}`), nil
+		case "/wellness-service/wellness/floorsChartData/daily/2024-08-16":
+			return jsonResponse(req, http.StatusOK, `{
+				"startTimestampGMT": "2024-08-16T07:00:00.0",
+				"floorValuesArray": [["2024-08-16T14:00:00.0", "2024-08-16T14:15:00.0", 3, 1], ["2024-08-16T14:15:00.0", "2024-08-16T14:30:00.0", 2, 4]]
+			}`), nil
+		}
+		return jsonResponse(req, http.StatusNotFound, `{}`), nil
+	}}
+	api := NewAPI(NewClient(WithTransport(transport)))
+	date := time.Date(2024, 8, 16, 0, 0, 0, 0, time.UTC)
+
+	spo2, err := api.Wellness.DailySpO2(date)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *spo2.LowestSpO2 != 84 || len(spo2.HourlyAverages) != 2 || spo2.HourlyAverages[1].Value != 94 {
+		t.Errorf("spo2: %+v", spo2)
+	}
+	if r := spo2.ContinuousReadings; len(r) != 1 || r[0].Reading != 90 {
+		t.Errorf("readings: %+v", r)
+	}
+
+	resp, err := api.Wellness.DailyRespiration(date)
+	if err != nil {
+		t.Fatal(err)
+	}
+	v := resp.RespirationValues
+	if len(v) != 2 || v[0].Time.Unix() != 1723766400 || v[1].Value != 13 {
+		t.Errorf("respiration: %+v", v)
+	}
+
+	floors, err := api.Wellness.DailyFloors(date)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if up, down := floors.Total(); up != 5 || down != 5 {
+		t.Errorf("floors: %d up, %d down", up, down)
+	}
+	if end := floors.Intervals[1].End.Time(); end != time.Date(2024, 8, 16, 14, 30, 0, 0, time.UTC) {
+		t.Errorf("interval end: %v", end)
+	}
+}